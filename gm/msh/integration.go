@@ -68,7 +68,7 @@ func (o *Integrator) ResetP(P [][]float64, pName string) {
 	} else if pName != "" { // find in database
 		o.P = IntPointsFindSet(TypeIndexToKind[o.Ctype], pName)
 	} else { // set default
-		o.P = DefaultIntPoints[o.Ctype]
+		o.P = GetDefaultIntPoints()[o.Ctype]
 	}
 	o.Npts = len(o.P)
 
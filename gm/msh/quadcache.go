@@ -0,0 +1,109 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package msh
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// DefaultCacheLimit is the default maximum number of entries in each cache of generated rules
+const DefaultCacheLimit = 256
+
+// SetCacheLimit sets the maximum number of entries in each cache of rules generated on demand;
+// i.e. the cache of 1D positions and weights shared by tensor-product rules and the cache of sets
+// generated by IntPointsGetSet. The least-recently-used entries beyond the limit are evicted
+//   NOTE: the limit must be at least 1. Use DefaultCacheLimit to restore the default
+func SetCacheLimit(n int) {
+	if n < 1 {
+		chk.Panic("cache limit must be at least 1. n = %d is invalid\n", n)
+	}
+	for _, c := range quadCaches() {
+		c.Lock()
+		c.setLimit(n)
+		c.Unlock()
+	}
+}
+
+// CacheStats returns the total number of entries, hits and misses of the caches of rules generated
+// on demand. See SetCacheLimit
+func CacheStats() (entries, hits, misses int) {
+	for _, c := range quadCaches() {
+		c.Lock()
+		entries += c.order.Len()
+		hits += c.hits
+		misses += c.misses
+		c.Unlock()
+	}
+	return
+}
+
+// quadCaches returns all caches of rules generated on demand
+func quadCaches() []*quadCache {
+	return []*quadCache{quadPoints1dCache, intPointsGenerated}
+}
+
+// quadCache implements a least-recently-used cache
+//   NOTE: the methods do not lock the mutex; callers must do so
+type quadCache struct {
+	sync.Mutex
+	limit  int                           // maximum number of entries
+	order  *list.List                    // entries sorted from the most to the least recently used
+	items  map[interface{}]*list.Element // maps key to element of order
+	hits   int                           // number of successful calls to get
+	misses int                           // number of unsuccessful calls to get
+}
+
+// quadCacheEntry holds a key and its value in quadCache.order
+type quadCacheEntry struct {
+	key   interface{}
+	value interface{}
+}
+
+// newQuadCache returns a new cache
+func newQuadCache(limit int) (o *quadCache) {
+	return &quadCache{limit: limit, order: list.New(), items: make(map[interface{}]*list.Element)}
+}
+
+// get returns the value corresponding to key and marks it as the most recently used
+func (o *quadCache) get(key interface{}) (value interface{}, ok bool) {
+	e, ok := o.items[key]
+	if !ok {
+		o.misses++
+		return
+	}
+	o.hits++
+	o.order.MoveToFront(e)
+	return e.Value.(*quadCacheEntry).value, true
+}
+
+// put sets the value corresponding to key as the most recently used and evicts the least recently
+// used entries beyond the limit
+func (o *quadCache) put(key, value interface{}) {
+	if e, ok := o.items[key]; ok {
+		e.Value.(*quadCacheEntry).value = value
+		o.order.MoveToFront(e)
+		return
+	}
+	o.items[key] = o.order.PushFront(&quadCacheEntry{key, value})
+	o.evict()
+}
+
+// setLimit sets the maximum number of entries and evicts the least recently used ones beyond it
+func (o *quadCache) setLimit(n int) {
+	o.limit = n
+	o.evict()
+}
+
+// evict removes the least recently used entries beyond the limit
+func (o *quadCache) evict() {
+	for o.order.Len() > o.limit {
+		e := o.order.Back()
+		delete(o.items, e.Value.(*quadCacheEntry).key)
+		o.order.Remove(e)
+	}
+}
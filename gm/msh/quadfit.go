@@ -0,0 +1,191 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package msh
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/num"
+)
+
+// NewIntPointsSetFromMoments computes a custom set of integration points reproducing given moments
+// by means of a weighted least-squares fit solved with num.NlSolver (Gauss-Newton)
+//
+//   typeKey -- type of shape; e.g. "lin2", "tri3" or "qua4" (i.e. ndim must be 1 or 2)
+//   npts    -- number of points
+//   moments -- target moments: maps (a,b) to ∫ r^a s^b dΩ over the reference cell
+//
+//   NOTE: (1) there must be at least npts*(ndim+1) moments; i.e. the number of unknowns
+//         (2) each moment equation is weighted by 1/max(1,|moment|)
+//         (3) the initial guess is a ring of points around the centroid of the reference cell;
+//             thus, the solution is not unique and, e.g., the points may come out permuted
+//         (4) an error is returned if the moments cannot be reproduced within 1e-10
+func NewIntPointsSetFromMoments(typeKey string, npts int, moments map[[2]int]float64) (o *IntPointsSet, err error) {
+
+	// check input
+	typeIndex, ok := TypeKeyToIndex[typeKey]
+	if !ok {
+		return nil, chk.Err("type key %q is invalid\n", typeKey)
+	}
+	cellKind := TypeIndexToKind[typeIndex]
+	ndim := kindNdim(cellKind)
+	if ndim > 2 {
+		return nil, chk.Err("fitting moments is only available for ndim = 1 or 2. %q is invalid\n", typeKey)
+	}
+	if npts < 1 {
+		return nil, chk.Err("number of points must be at least 1. npts = %d is invalid\n", npts)
+	}
+	nu := npts * (ndim + 1) // number of unknowns
+	nm := len(moments)      // number of equations
+	if nm < nu {
+		return nil, chk.Err("number of moments (%d) must be greater than or equal to the number of unknowns (%d)\n", nm, nu)
+	}
+
+	// sorted exponents and weights of equations
+	exps := make([][2]int, 0, nm)
+	for ab := range moments {
+		if ab[0] < 0 || ab[1] < 0 || (ndim == 1 && ab[1] != 0) {
+			return nil, chk.Err("exponents %v are invalid for %q\n", ab, typeKey)
+		}
+		exps = append(exps, ab)
+	}
+	sort.Slice(exps, func(i, j int) bool {
+		if exps[i][0] == exps[j][0] {
+			return exps[i][1] < exps[j][1]
+		}
+		return exps[i][0] < exps[j][0]
+	})
+	ω := make([]float64, nm)
+	for k, ab := range exps {
+		ω[k] = 1.0 / math.Max(1, math.Abs(moments[ab]))
+	}
+
+	// residuals and Jacobian of moment equations
+	// u = {x0, y0, w0, x1, y1, w1, ...}  ⇒  rk = ωk (Σ wi xi^a yi^b - mk)
+	pow := func(x float64, n int) float64 {
+		if n < 1 {
+			return 1
+		}
+		return math.Pow(x, float64(n))
+	}
+	residuals := func(r la.Vector, J *la.Matrix, u la.Vector) {
+		for k, ab := range exps {
+			r[k] = -moments[ab]
+			for i := 0; i < npts; i++ {
+				x, y, w := u[i*(ndim+1)], 0.0, u[i*(ndim+1)+ndim]
+				if ndim == 2 {
+					y = u[i*(ndim+1)+1]
+				}
+				xa, yb := pow(x, ab[0]), pow(y, ab[1])
+				r[k] += w * xa * yb
+				if J != nil {
+					J.Set(k, i*(ndim+1), ω[k]*w*float64(ab[0])*pow(x, ab[0]-1)*yb)
+					if ndim == 2 {
+						J.Set(k, i*(ndim+1)+1, ω[k]*w*xa*float64(ab[1])*pow(y, ab[1]-1))
+					}
+					J.Set(k, i*(ndim+1)+ndim, ω[k]*xa*yb)
+				}
+			}
+			r[k] *= ω[k]
+		}
+	}
+
+	// normal equations: f(u) = Jᵀ r = 0 with Gauss-Newton Jacobian dfdu ≈ Jᵀ J
+	r := la.NewVector(nm)
+	J := la.NewMatrix(nm, nu)
+	ffcn := func(f, u la.Vector) {
+		residuals(r, J, u)
+		for i := 0; i < nu; i++ {
+			f[i] = 0
+			for k := 0; k < nm; k++ {
+				f[i] += J.Get(k, i) * r[k]
+			}
+		}
+	}
+	Jfcn := func(dfdu *la.Matrix, u la.Vector) {
+		residuals(r, J, u)
+		for i := 0; i < nu; i++ {
+			for j := 0; j < nu; j++ {
+				dfdu.Set(i, j, 0)
+				for k := 0; k < nm; k++ {
+					dfdu.Add(i, j, J.Get(k, i)*J.Get(k, j))
+				}
+			}
+		}
+	}
+
+	// initial guess
+	measure, _ := ReferenceMeasure(cellKind)
+	xc, radius := []float64{0, 0}, 0.5
+	if cellKind == KindTri {
+		xc, radius = []float64{1.0 / 3.0, 1.0 / 3.0}, 0.2
+	}
+	u := la.NewVector(nu)
+	for i := 0; i < npts; i++ {
+		if ndim == 1 {
+			u[i*2] = -1.0 + 2.0*float64(i+1)/float64(npts+1)
+		} else if npts > 1 {
+			α := 2.0 * math.Pi * (float64(i) + 0.5) / float64(npts)
+			u[i*3] = xc[0] + radius*math.Cos(α)
+			u[i*3+1] = xc[1] + radius*math.Sin(α)
+		} else {
+			u[i*3], u[i*3+1] = xc[0], xc[1]
+		}
+		u[i*(ndim+1)+ndim] = measure / float64(npts)
+	}
+
+	// solve nonlinear problem
+	err = fitMomentsSolve(nu, ffcn, Jfcn, u)
+	if err != nil {
+		return
+	}
+
+	// check results
+	residuals(r, nil, u)
+	for k, ab := range exps {
+		if math.Abs(r[k]/ω[k]) > 1e-10 {
+			return nil, chk.Err("cannot reproduce moment %v: error = %g\n", ab, math.Abs(r[k]/ω[k]))
+		}
+	}
+
+	// set of points
+	P := make([][]float64, npts)
+	for i := 0; i < npts; i++ {
+		P[i] = make([]float64, 4)
+		copy(P[i], u[i*(ndim+1):i*(ndim+1)+ndim])
+		P[i][3] = u[i*(ndim+1)+ndim]
+	}
+	o = &IntPointsSet{Kind: cellKind, Name: io.Sf("moments_%d", npts), Ndim: ndim, Npts: npts, P: P}
+	return
+}
+
+// fitMomentsSolve runs the nonlinear solver converting failures into errors
+func fitMomentsSolve(neq int, ffcn fun.Vv, Jfcn fun.Mv, u la.Vector) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = chk.Err("nonlinear solver failed: %v\n", e)
+		}
+	}()
+	solverParams := map[string]float64{
+		"atol":    1e-12,
+		"rtol":    1e-12,
+		"ftol":    1e-14,
+		"maxIt":   100,
+		"chkConv": 0,
+	}
+	silent := true
+	useDenseJacobian := true
+	numericalJacobian := false
+	var solver num.NlSolver
+	defer solver.Free()
+	solver.Init(neq, ffcn, nil, Jfcn, useDenseJacobian, numericalJacobian, solverParams)
+	solver.Solve(u, silent)
+	return
+}
@@ -0,0 +1,155 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package msh
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// SimplexOrbit defines a symmetry orbit of integration points in triangles or tetrahedra by means of
+// barycentric coordinates. All points of the orbit have the same weight
+//
+//   Triangles: barycentric coordinates (λ0,λ1,λ2) with λ0+λ1+λ2=1
+//     "s3"    -- centroid (1/3,1/3,1/3); 1 point; no parameters
+//     "s21"   -- permutations of (a,a,1-2a); 3 points; Prms = {a}
+//     "s111"  -- permutations of (a,b,1-a-b); 6 points; Prms = {a,b}
+//
+//   Tetrahedra: barycentric coordinates (λ0,λ1,λ2,λ3) with λ0+λ1+λ2+λ3=1
+//     "s4"    -- centroid (1/4,1/4,1/4,1/4); 1 point; no parameters
+//     "s31"   -- permutations of (a,a,a,1-3a); 4 points; Prms = {a}
+//     "s22"   -- permutations of (a,a,1/2-a,1/2-a); 6 points; Prms = {a}
+//     "s211"  -- permutations of (a,a,b,1-2a-b); 12 points; Prms = {a,b}
+//     "s1111" -- permutations of (a,b,c,1-a-b-c); 24 points; Prms = {a,b,c}
+//
+//   NOTE: the reference coordinates (r,s) or (r,s,t) are the first 2 or 3 barycentric coordinates
+type SimplexOrbit struct {
+	Type string    // type of orbit; e.g. "s21"
+	Prms []float64 // barycentric parameters; e.g. {a} for "s21"
+	W    float64   // weight of each point
+}
+
+// QuadPointsFromOrbits generates quadrature points for the reference triangle or tetrahedron by
+// expanding all permutations of the given symmetry orbits
+//   cellKind -- KindTri or KindTet
+//   orbits   -- symmetry orbits
+//   NOTE: the points of each orbit are generated in lexicographical order of permutations
+func QuadPointsFromOrbits(cellKind int, orbits []SimplexOrbit) (pts [][]float64) {
+	var nvert int
+	switch cellKind {
+	case KindTri:
+		nvert = 3
+	case KindTet:
+		nvert = 4
+	default:
+		chk.Panic("orbits are only available for triangles and tetrahedra. cellKind = %d is invalid\n", cellKind)
+	}
+	for _, orbit := range orbits {
+		λ := simplexOrbitBarycentric(nvert, orbit)
+		for _, perm := range ExpandSimplexOrbit(λ) {
+			p := make([]float64, 4)
+			copy(p, perm[:nvert-1])
+			p[3] = orbit.W
+			pts = append(pts, p)
+		}
+	}
+	return
+}
+
+// ExpandSimplexOrbit returns all distinct permutations of the barycentric coordinates of a point
+// in the reference triangle or tetrahedron; i.e. the points of the symmetry orbit of λ
+//   λ -- barycentric coordinates (λ0,λ1,λ2) of triangles or (λ0,λ1,λ2,λ3) of tetrahedra with Σλi=1
+//   NOTE: (1) the number of points (multiplicity of the orbit) depends on the number of repeated
+//             values in λ; e.g. 1 for the centroid and 6 for three distinct values in triangles
+//         (2) the reference coordinates (r,s) or (r,s,t) are the first 2 or 3 barycentric coordinates
+//         (3) the permutations are generated in lexicographical order
+func ExpandSimplexOrbit(λ []float64) (res [][]float64) {
+	if len(λ) != 3 && len(λ) != 4 {
+		chk.Panic("number of barycentric coordinates must be 3 (tri) or 4 (tet). %d is invalid\n", len(λ))
+	}
+	sum := 0.0
+	for _, v := range λ {
+		sum += v
+	}
+	if math.Abs(sum-1) > 1e-14 {
+		chk.Panic("sum of barycentric coordinates must be equal to 1. %v is invalid\n", sum)
+	}
+	return distinctPermutations(λ)
+}
+
+// simplexOrbitBarycentric returns the barycentric coordinates of the generator of an orbit
+func simplexOrbitBarycentric(nvert int, orbit SimplexOrbit) (λ []float64) {
+	type orbitDef struct {
+		nvert, nprms int
+	}
+	defs := map[string]orbitDef{
+		"s3": {3, 0}, "s21": {3, 1}, "s111": {3, 2},
+		"s4": {4, 0}, "s31": {4, 1}, "s22": {4, 1}, "s211": {4, 2}, "s1111": {4, 3},
+	}
+	def, ok := defs[orbit.Type]
+	if !ok || def.nvert != nvert {
+		chk.Panic("orbit type %q is invalid for simplex with %d vertices\n", orbit.Type, nvert)
+	}
+	if len(orbit.Prms) != def.nprms {
+		chk.Panic("orbit type %q requires %d parameters. %d is invalid\n", orbit.Type, def.nprms, len(orbit.Prms))
+	}
+	a := orbit.Prms
+	switch orbit.Type {
+	case "s3":
+		return []float64{1.0 / 3.0, 1.0 / 3.0, 1.0 / 3.0}
+	case "s21":
+		return []float64{a[0], a[0], 1 - 2*a[0]}
+	case "s111":
+		return []float64{a[0], a[1], 1 - a[0] - a[1]}
+	case "s4":
+		return []float64{0.25, 0.25, 0.25, 0.25}
+	case "s31":
+		return []float64{a[0], a[0], a[0], 1 - 3*a[0]}
+	case "s22":
+		return []float64{a[0], a[0], 0.5 - a[0], 0.5 - a[0]}
+	case "s211":
+		return []float64{a[0], a[0], a[1], 1 - 2*a[0] - a[1]}
+	}
+	return []float64{a[0], a[1], a[2], 1 - a[0] - a[1] - a[2]} // s1111
+}
+
+// distinctPermutations returns all distinct permutations of the values in λ. Permutations are
+// generated in lexicographical order of positions and repeated ones (due to equal values) are skipped
+func distinctPermutations(λ []float64) (res [][]float64) {
+	n := len(λ)
+	used := make([]bool, n)
+	cur := make([]float64, 0, n)
+	var recurse func()
+	recurse = func() {
+		if len(cur) == n {
+			for _, r := range res {
+				same := true
+				for i := range r {
+					if r[i] != cur[i] {
+						same = false
+						break
+					}
+				}
+				if same {
+					return
+				}
+			}
+			res = append(res, append([]float64{}, cur...))
+			return
+		}
+		for i := 0; i < n; i++ {
+			if !used[i] {
+				used[i] = true
+				cur = append(cur, λ[i])
+				recurse()
+				cur = cur[:len(cur)-1]
+				used[i] = false
+			}
+		}
+	}
+	recurse()
+	return
+}
@@ -6,8 +6,15 @@ package msh
 
 import (
 	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/io"
 	"github.com/cpmech/gosl/num"
 	"github.com/cpmech/gosl/plt"
 	"github.com/cpmech/gosl/utl"
@@ -16,50 +23,900 @@ import (
 // QuadPointsGaussLegendre generate quadrature points for Gauss-Legendre integration
 //    npts -- is the total number of points; e.g. 27 for 3D (boxes)
 func QuadPointsGaussLegendre(ndim, npts int) (pts [][]float64) {
-	n1d := int(math.Floor(math.Pow(float64(npts), 1.0/float64(ndim)) + 0.5))
-	x, w := num.GaussLegendreXW(-1, 1, n1d)
+	n1d := quadPointsN1d(ndim, npts)
+	return QuadPointsGaussTensor("legendre", utl.IntVals(ndim, n1d))
+}
+
+// QuadPointsGaussLegendreAB generate 1D quadrature points for Gauss-Legendre integration over [a,b]
+//    a, b -- limits of the segment; a < b
+//    npts -- number of points
+//   NOTE: the weights are multiplied by (b-a)/2; i.e. the points can be used to integrate
+//         functions along physical segments directly
+func QuadPointsGaussLegendreAB(a, b float64, npts int) (pts [][]float64) {
+	if a >= b {
+		chk.Panic("the lower limit of segment must be smaller than the upper limit. a=%g and b=%g are invalid\n", a, b)
+	}
+	x, w := quadPoints1d("legendre", npts)
+	xm, xl := (b+a)/2.0, (b-a)/2.0
+	pts = make([][]float64, npts)
+	for i := 0; i < npts; i++ {
+		pts[i] = []float64{xm + xl*x[i], 0, 0, xl * w[i]}
+	}
+	return
+}
+
+// QuadPointsGaussKronrod generates a pair of 1D quadrature rules for the estimation of errors:
+// the Gauss-Legendre rule with npts points and its Kronrod extension with 2⋅npts+1 points.
+// The Kronrod rule reuses the Gauss-Legendre positions; thus, the error estimate
+// |I_kronrod - I_gauss| only requires npts+1 additional function evaluations
+func QuadPointsGaussKronrod(npts int) (gauss, kronrod [][]float64) {
+	x, wk, wg := num.GaussKronrodXW(-1, 1, npts)
+	gauss = make([][]float64, 0, npts)
+	kronrod = make([][]float64, len(x))
+	for i := 0; i < len(x); i++ {
+		kronrod[i] = []float64{x[i], 0, 0, wk[i]}
+		if wg[i] > 0 {
+			gauss = append(gauss, []float64{x[i], 0, 0, wg[i]})
+		}
+	}
+	return
+}
+
+// QuadPointsClenshawCurtis generates 1D quadrature points for the nested Clenshaw-Curtis rule of a
+// given level over [-1,1]; i.e. with 1 point for level 1 and 2^(level-1)+1 points otherwise. The
+// points of each level are also points of the next level. See IntPointsSet.Refine
+func QuadPointsClenshawCurtis(level int) (pts [][]float64) {
+	if level < 1 || level > 20 {
+		chk.Panic("level of Clenshaw-Curtis rule must be in [1, 20]. level = %d is invalid\n", level)
+	}
+	m := quadPointsClenshawCurtisM(level)
+	x, w := quadPointsClenshawCurtisX(m), quadPointsClenshawCurtisW(m)
+	pts = make([][]float64, m)
+	for i := 0; i < m; i++ {
+		pts[i] = []float64{x[i], 0, 0, w[i]}
+	}
+	return
+}
+
+// QuadPointsGaussLaguerre generates 1D quadrature points for Gauss-Laguerre integration over the
+// semi-infinite domain [0,∞) with weight function exp(-x). The points are sorted in ascending order
+//   NOTE: the weight function is absorbed in the weights; i.e. QuadPointsIntegrate computes
+//         ∫ f(x)⋅exp(-x) dx and not the bare integral of f(x)
+func QuadPointsGaussLaguerre(npts int) (pts [][]float64) {
+	x, w := num.GaussLaguerreXW(0, npts)
+	pts = make([][]float64, npts)
+	for i := 0; i < npts; i++ {
+		pts[i] = []float64{x[i], 0, 0, w[i]}
+	}
+	return
+}
+
+// QuadPointsGaussHermite generates 1D quadrature points for Gauss-Hermite integration over the
+// infinite domain (-∞,∞) with weight function exp(-x²). The points are sorted in ascending order
+//   NOTE: the weight function is absorbed in the weights; i.e. QuadPointsIntegrate computes
+//         ∫ f(x)⋅exp(-x²) dx and not the bare integral of f(x)
+func QuadPointsGaussHermite(npts int) (pts [][]float64) {
+	x, w := num.GaussHermiteXW(npts)
+	pts = make([][]float64, npts)
+	for i := 0; i < npts; i++ {
+		pts[i] = []float64{x[npts-1-i], 0, 0, w[npts-1-i]}
+	}
+	return
+}
+
+// QuadPointsGaussLobatto generate quadrature points for Gauss-Lobatto-Legendre integration
+//    npts -- is the total number of points; e.g. 27 for 3D (boxes)
+//   NOTE: the points include the boundaries of the reference cell (e.g. ±1 in 1D); thus,
+//         these points can be used as nodes of spectral elements
+func QuadPointsGaussLobatto(ndim, npts int) (pts [][]float64) {
+	n1d := quadPointsN1d(ndim, npts)
+	return QuadPointsGaussTensor("lobatto", utl.IntVals(ndim, n1d))
+}
+
+// QuadPointsLobattoNodes returns the n Gauss-Lobatto-Legendre positions over [-1,1] sorted in
+// ascending order; e.g. to place the nodes of spectral elements. The positions are the roots of
+// (1-x²)⋅P'_{n-1}(x), where P_{n-1} is the Legendre polynomial of degree n-1; thus, x[0] = -1
+// and x[n-1] = +1
+//   n -- number of nodes. n ≥ 2
+func QuadPointsLobattoNodes(n int) (x []float64) {
+	xx, _ := quadPoints1d("lobatto", n)
+	x = make([]float64, n)
+	copy(x, xx)
+	return
+}
+
+// QuadPointsGaussRadau generate quadrature points for Gauss-Radau-Legendre integration
+//    npts     -- is the total number of points; e.g. 27 for 3D (boxes)
+//    fixRight -- include the +1 instead of the -1 boundary along each direction
+func QuadPointsGaussRadau(ndim, npts int, fixRight bool) (pts [][]float64) {
+	n1d := quadPointsN1d(ndim, npts)
+	if fixRight {
+		return QuadPointsGaussTensor("radauright", utl.IntVals(ndim, n1d))
+	}
+	return QuadPointsGaussTensor("radauleft", utl.IntVals(ndim, n1d))
+}
+
+// QuadPointsNewtonCotes generate quadrature points for closed Newton-Cotes integration; i.e.
+// with equally spaced points including the boundaries of the reference cell
+//    npts -- is the total number of points; e.g. 27 for 3D (boxes)
+//   NOTE: (1) the number of points along each direction must be in [2, 7]
+//         (2) high order Newton-Cotes rules are unstable: the weights grow in magnitude and
+//             become negative (for 9 or more points along each direction) and the interpolating
+//             polynomials oscillate (Runge's phenomenon). Gauss rules should be preferred.
+func QuadPointsNewtonCotes(ndim, npts int) (pts [][]float64) {
+	n1d := quadPointsN1d(ndim, npts)
+	return QuadPointsGaussTensor("newtoncotes", utl.IntVals(ndim, n1d))
+}
+
+// QuadPointsNewtonCotesOpen generate quadrature points for open Newton-Cotes integration; i.e.
+// with equally spaced points excluding the boundaries of the reference cell; e.g. for integrands
+// which are singular on the boundaries
+//    npts -- is the total number of points; e.g. 27 for 3D (boxes)
+//   NOTE: (1) the number of points along each direction must be in [1, 5]
+//         (2) the weights are negative for 3 and 5 points along each direction
+func QuadPointsNewtonCotesOpen(ndim, npts int) (pts [][]float64) {
+	n1d := quadPointsN1d(ndim, npts)
+	return QuadPointsGaussTensor("newtoncotesopen", utl.IntVals(ndim, n1d))
+}
+
+// QuadPointsRuleIsOpen returns whether a 1D rule is open or not; i.e. whether the boundaries of
+// the reference cell are excluded from the set of points or not
+//   rule -- "legendre", "lobatto", "radauleft", "radauright", "newtoncotes" or "newtoncotesopen"
+//   NOTE: "legendre" and "newtoncotesopen" are open; the others are closed (or half-open)
+func QuadPointsRuleIsOpen(rule string) bool {
+	switch rule {
+	case "legendre", "newtoncotesopen":
+		return true
+	case "lobatto", "radauleft", "radauright", "newtoncotes":
+		return false
+	}
+	chk.Panic("rule %q is invalid\n", rule)
+	return false
+}
+
+// QuadPointsMidpoint generate one quadrature point at the centre of the reference segment, square
+// or cube, with weight equal to the measure of the reference cell
+//   NOTE: only polynomials of degree 1 are integrated exactly. This rule is useful as a baseline
+//         for comparisons and for debugging
+func QuadPointsMidpoint(ndim int) (pts [][]float64) {
+	if ndim < 1 || ndim > 3 {
+		chk.Panic("ndim=%d is invalid. ndim must be 1, 2, or 3\n", ndim)
+	}
+	return [][]float64{{0, 0, 0, math.Pow(2, float64(ndim))}}
+}
+
+// QuadPointsTrapezoidal generate quadrature points at the vertices of the reference segment, square
+// or cube, with equal weights
+//   NOTE: only polynomials of degree 1 along each direction (e.g. bilinear functions on the square)
+//         are integrated exactly. This rule is useful as a baseline for comparisons and to
+//         compute lumped mass matrices
+func QuadPointsTrapezoidal(ndim int) (pts [][]float64) {
+	if ndim < 1 || ndim > 3 {
+		chk.Panic("ndim=%d is invalid. ndim must be 1, 2, or 3\n", ndim)
+	}
+	return QuadPointsGaussTensor("newtoncotes", utl.IntVals(ndim, 2))
+}
+
+// QuadPointsLattice generate a regular lattice of sample points within the reference cell; e.g.
+// to sample scalar fields for visualisation. All points have the same weight such that the sum of
+// weights equals the measure of the reference cell (i.e. a crude midpoint quadrature)
+//    cellKind -- KindLin, KindTri, KindQua, KindTet or KindHex
+//    nPerDim  -- number of points along each direction
+//   NOTE: (1) lin, qua and hex: the points are the centres of nPerDim^ndim equal subcells
+//         (2) tri and tet: the points are (i+1/3, j+1/3)/nPerDim or (i+1/4, j+1/4, k+1/4)/nPerDim
+//             with i+j ≤ nPerDim-1 or i+j+k ≤ nPerDim-1; thus all points are inside the simplex
+//             and the total number of points is nPerDim⋅(nPerDim+1)/2 or
+//             nPerDim⋅(nPerDim+1)⋅(nPerDim+2)/6, respectively
+func QuadPointsLattice(cellKind, nPerDim int) (pts [][]float64) {
+	if nPerDim < 1 {
+		chk.Panic("number of points along each direction must be at least 1. nPerDim=%d is invalid\n", nPerDim)
+	}
+	n := nPerDim
+	switch cellKind {
+	case KindLin, KindQua, KindHex:
+		ndim := kindNdim(cellKind)
+		x := make([]float64, n)
+		for i := 0; i < n; i++ {
+			x[i] = -1.0 + float64(2*i+1)/float64(n)
+		}
+		X := make([][]float64, ndim)
+		W := make([][]float64, ndim)
+		for d := 0; d < ndim; d++ {
+			X[d], W[d] = x, utl.Vals(n, 2.0/float64(n))
+		}
+		return quadPointsTensor(X, W)
+	case KindTri:
+		for j := 0; j < n; j++ {
+			for i := 0; i+j < n; i++ {
+				pts = append(pts, []float64{(float64(i) + 1.0/3.0) / float64(n), (float64(j) + 1.0/3.0) / float64(n), 0, 0})
+			}
+		}
+	case KindTet:
+		for k := 0; k < n; k++ {
+			for j := 0; j+k < n; j++ {
+				for i := 0; i+j+k < n; i++ {
+					pts = append(pts, []float64{(float64(i) + 0.25) / float64(n), (float64(j) + 0.25) / float64(n), (float64(k) + 0.25) / float64(n), 0})
+				}
+			}
+		}
+	default:
+		chk.Panic("lattice of points is not available for cellKind = %d\n", cellKind)
+	}
+	measure, _ := ReferenceMeasure(cellKind)
+	for _, p := range pts {
+		p[3] = measure / float64(len(pts))
+	}
+	return
+}
+
+// QuadPointsMonteCarlo generate pseudo-random quadrature points uniformly distributed within the
+// reference cell with equal weights such that the sum of weights equals the measure of the cell;
+// e.g. for non-smooth integrands
+//    cellKind -- kind of cell; e.g. KindTri
+//    npts     -- number of points
+//    seed     -- seed of the pseudo-random number generator; the same seed yields the same points
+//   NOTE: (1) the points are sampled within the bounding box of the reference cell and rejected
+//             if outside the cell (e.g. tri, tet, wed and pyr)
+//         (2) the error decreases with 1/√npts; i.e. slowly
+//         (3) a private generator is used; thus, the global state of math/rand (or rnd) is not
+//             changed and concurrent calls are safe
+func QuadPointsMonteCarlo(cellKind, npts int, seed int64) (pts [][]float64) {
+	if npts < 1 {
+		chk.Panic("number of points must be at least 1. npts=%d is invalid\n", npts)
+	}
+	measure, err := ReferenceMeasure(cellKind)
+	if err != nil {
+		chk.Panic("%v", err)
+	}
+	xmin, xmax := []float64{-1, -1, -1}, []float64{1, 1, 1}
+	switch cellKind {
+	case KindTri, KindTet:
+		xmin = []float64{0, 0, 0}
+	case KindWed:
+		xmin[0], xmin[1] = 0, 0
+	case KindPyr:
+		xmin[2] = 0
+	}
+	ndim := kindNdim(cellKind)
+	rng := rand.New(rand.NewSource(seed))
+	pts = make([][]float64, 0, npts)
+	for len(pts) < npts {
+		p := []float64{0, 0, 0, measure / float64(npts)}
+		for d := 0; d < ndim; d++ {
+			p[d] = xmin[d] + (xmax[d]-xmin[d])*rng.Float64()
+		}
+		if InReferenceCell(cellKind, p, 0) {
+			pts = append(pts, p)
+		}
+	}
+	return
+}
+
+// QuadPointsQuasiMonteCarlo generate quasi-random (low-discrepancy) quadrature points within the
+// reference cell with equal weights such that the sum of weights equals the measure of the cell
+//    cellKind -- kind of cell; e.g. KindTri
+//    npts     -- number of points
+//    sequence -- "sobol" or "halton"
+//   NOTE: (1) the sequences are generated in the unit hypercube [0,1]^ndim (skipping the origin)
+//             and mapped to the reference cell by volume-preserving transformations; thus, there
+//             is no rejection and the number of points is exact
+//         (2) the error decreases approximately with 1/npts for smooth integrands; i.e. faster
+//             than with QuadPointsMonteCarlo
+//   References:
+//   [1] Joe S, Kuo FY (2008) Constructing Sobol sequences with better two-dimensional projections.
+//       SIAM Journal on Scientific Computing, 30:2635-2654
+func QuadPointsQuasiMonteCarlo(cellKind, npts int, sequence string) (pts [][]float64) {
+	if npts < 1 {
+		chk.Panic("number of points must be at least 1. npts=%d is invalid\n", npts)
+	}
+	measure, err := ReferenceMeasure(cellKind)
+	if err != nil {
+		chk.Panic("%v", err)
+	}
+	var U [][]float64
+	switch sequence {
+	case "sobol":
+		U = quadPointsSobol(npts)
+	case "halton":
+		U = quadPointsHalton(npts)
+	default:
+		chk.Panic("sequence %q is invalid. options are \"sobol\" and \"halton\"\n", sequence)
+	}
+	ndim := kindNdim(cellKind)
+	pts = make([][]float64, npts)
+	for i, u := range U {
+		p := []float64{0, 0, 0, measure / float64(npts)}
+		switch cellKind {
+		case KindLin, KindQua, KindHex:
+			for d := 0; d < ndim; d++ {
+				p[d] = -1.0 + 2.0*u[d]
+			}
+		case KindTri, KindWed:
+			su := math.Sqrt(u[0]) // the length of the cross-section r+s=√u is proportional to √u
+			p[0], p[1] = su*(1.0-u[1]), su*u[1]
+			if cellKind == KindWed {
+				p[2] = -1.0 + 2.0*u[2]
+			}
+		case KindTet:
+			ρ := math.Cbrt(u[0]) // the area of the cross-section r+s+t=ρ is proportional to ρ²
+			su := math.Sqrt(u[1])
+			p[0], p[1], p[2] = ρ*(1.0-su), ρ*su*(1.0-u[2]), ρ*su*u[2]
+		case KindPyr:
+			t := 1.0 - math.Cbrt(1.0-u[2]) // the area of the cross-section is proportional to (1-t)²
+			p[0], p[1], p[2] = (1.0-t)*(2.0*u[0]-1.0), (1.0-t)*(2.0*u[1]-1.0), t
+		}
+		pts[i] = p
+	}
+	return
+}
+
+// quadPointsSobol returns the first npts points of the 3D Sobol sequence (skipping the origin)
+// with direction numbers from Joe and Kuo; the points are within [0,1)³
+func quadPointsSobol(npts int) (U [][]float64) {
+	const nbits = 32
+	type prim struct {
+		s, a int   // degree and coefficients of primitive polynomial
+		m    []int // initial direction numbers
+	}
+	prims := []prim{{1, 0, []int{1}}, {2, 1, []int{1, 3}}}
+	V := make([][nbits + 1]uint32, 3)
+	for k := 1; k <= nbits; k++ {
+		V[0][k] = 1 << uint(nbits-k)
+	}
+	for d, p := range prims {
+		v := &V[d+1]
+		for k := 1; k <= nbits; k++ {
+			if k <= p.s {
+				v[k] = uint32(p.m[k-1]) << uint(nbits-k)
+				continue
+			}
+			v[k] = v[k-p.s] ^ (v[k-p.s] >> uint(p.s))
+			for j := 1; j < p.s; j++ {
+				if (p.a>>uint(p.s-1-j))&1 == 1 {
+					v[k] ^= v[k-j]
+				}
+			}
+		}
+	}
+	X := make([]uint32, 3)
+	U = make([][]float64, npts)
+	for i := 1; i <= npts; i++ {
+		c := 1 // position of the rightmost zero bit of i-1 (Gray code)
+		for n := i - 1; n&1 == 1; n >>= 1 {
+			c++
+		}
+		U[i-1] = make([]float64, 3)
+		for d := 0; d < 3; d++ {
+			X[d] ^= V[d][c]
+			U[i-1][d] = float64(X[d]) / math.Pow(2, nbits)
+		}
+	}
+	return
+}
+
+// quadPointsHalton returns the first npts points of the 3D Halton sequence with bases 2, 3 and 5
+// (skipping the origin); the points are within (0,1)³
+func quadPointsHalton(npts int) (U [][]float64) {
+	bases := []int{2, 3, 5}
+	U = make([][]float64, npts)
+	for i := 1; i <= npts; i++ {
+		U[i-1] = make([]float64, 3)
+		for d, b := range bases {
+			f, r := 1.0, 0.0
+			for n := i; n > 0; n /= b {
+				f /= float64(b)
+				r += f * float64(n%b)
+			}
+			U[i-1][d] = r
+		}
+	}
+	return
+}
+
+// QuadPointsGaussJacobi generate 1D quadrature points for Gauss-Jacobi integration over [-1,1]
+//    alpha, beta -- coefficients of the weight function (1-x)^alpha ⋅ (1+x)^beta; alpha, beta > -1
+//    npts        -- number of points
+//   NOTE: the weight function is included in the weights; i.e. the points integrate g(x) in
+//         ∫ (1-x)^alpha ⋅ (1+x)^beta ⋅ g(x) dx. Polynomials g of degree up to 2⋅npts-1 are
+//         integrated exactly
+func QuadPointsGaussJacobi(alpha, beta float64, npts int) (pts [][]float64) {
+	if alpha <= -1 || beta <= -1 {
+		chk.Panic("coefficients of Gauss-Jacobi quadrature must be greater than -1. alpha=%g and beta=%g are invalid\n", alpha, beta)
+	}
+	x, w := num.GaussJacobiXW(alpha, beta, npts)
+	return quadPointsTensor([][]float64{x}, [][]float64{w})
+}
+
+// QuadPointsDuffyTri generate quadrature points for the reference triangle by collapsing the
+// reference square (Duffy transformation). Gauss-Legendre points are used along the first
+// direction and Gauss-Jacobi points (with alpha=1 and beta=0) along the second direction
+//    npts -- is the total number of points; e.g. 16 (4 points along each direction)
+//   NOTE: (1) the weights are positive and polynomials of degree up to 2⋅n1d-1 are integrated
+//             exactly, where n1d is the number of points along each direction
+//         (2) the mapping from the square (ξ,η) to the triangle (r,s) is:
+//               r = (1+ξ)⋅(1-η)/4   and   s = (1+η)/2
+func QuadPointsDuffyTri(npts int) (pts [][]float64) {
+	n1d := quadPointsN1d(2, npts)
+	xl, wl := quadPoints1d("legendre", n1d)
+	xj, wj := num.GaussJacobiXW(1, 0, n1d) // (1-η) is the Jacobian of the collapse
+	pts = make([][]float64, npts)
+	for j := 0; j < n1d; j++ {
+		for i := 0; i < n1d; i++ {
+			r := (1.0 + xl[i]) * (1.0 - xj[j]) / 4.0
+			s := (1.0 + xj[j]) / 2.0
+			pts[i+n1d*j] = []float64{r, s, 0, wl[i] * wj[j] / 8.0}
+		}
+	}
+	return
+}
+
+// QuadPointsWedge generate quadrature points for the reference wedge (triangular prism) by means of
+// the tensor product of a triangle rule in the (r,s) plane and a Gauss-Legendre rule along t
+//    triSetName -- name of set of integration points for triangles in GetIntPoints(); e.g. "internal_3"
+//    nLin       -- number of Gauss-Legendre points along t
+//   NOTE: the reference wedge is {(r,s,t) | r ≥ 0, s ≥ 0, r+s ≤ 1, -1 ≤ t ≤ 1} with volume 1;
+//         the points in the (r,s) plane run fastest
+func QuadPointsWedge(triSetName string, nLin int) (pts [][]float64) {
+	return quadPointsWedge(IntPointsFindSet(KindTri, triSetName), nLin)
+}
+
+// quadPointsWedge generate quadrature points for the reference wedge from a set of points for
+// triangles. See QuadPointsWedge
+//   NOTE: this function does not access the database; thus it can be used by intPointsInit
+func quadPointsWedge(tri [][]float64, nLin int) (pts [][]float64) {
+	return QuadPointsTensorProduct(tri, 2, QuadPointsGaussTensor("legendre", []int{nLin}), 1)
+}
+
+// QuadPointsPyramid generate quadrature points for the reference pyramid by collapsing the reference
+// cube. Gauss-Legendre points are used along the first two directions and Gauss-Jacobi points
+// (with alpha=2 and beta=0) along the third direction
+//    npts -- is the total number of points; e.g. 27 (3 points along each direction)
+//   NOTE: (1) the reference pyramid has the square base [-1,1]×[-1,1] at t=0 and the apex at
+//             (0,0,1); thus the volume is 4/3
+//         (2) the mapping from the cube (ξ,η,ζ) to the pyramid (r,s,t) is:
+//               t = (1+ζ)/2,   r = ξ⋅(1-t)   and   s = η⋅(1-t)
+//             and the Jacobian (1-ζ)²/8 is absorbed by the Gauss-Jacobi weights; therefore
+//             there are no points at the apex
+//         (3) the weights are positive and polynomials of degree up to 2⋅n1d-1 are integrated
+//             exactly, where n1d is the number of points along each direction
+func QuadPointsPyramid(npts int) (pts [][]float64) {
+	n1d := quadPointsN1d(3, npts)
+	xl, wl := quadPoints1d("legendre", n1d)
+	xj, wj := num.GaussJacobiXW(2, 0, n1d)
 	pts = make([][]float64, npts)
+	for k := 0; k < n1d; k++ {
+		t := (1.0 + xj[k]) / 2.0
+		for j := 0; j < n1d; j++ {
+			for i := 0; i < n1d; i++ {
+				m := i + n1d*j + n1d*n1d*k
+				pts[m] = []float64{xl[i] * (1.0 - t), xl[j] * (1.0 - t), t, wl[i] * wl[j] * wj[k] / 8.0}
+			}
+		}
+	}
+	return
+}
+
+// QuadPointsDisk generate quadrature points for the unit disk {(x,y) | x²+y² ≤ 1} using polar
+// coordinates: Gauss-Jacobi points along the radius (with the Jacobian r absorbed by the weights)
+// and equally spaced points along the angle (trapezoidal rule)
+//    nRadial  -- number of points along the radius
+//    nAngular -- number of points along the angle
+//   NOTE: (1) the sum of weights is π; i.e. the area of the disk
+//         (2) polynomials in r of degree up to 2⋅nRadial-1 times trigonometric polynomials in θ
+//             of degree up to nAngular-1 are integrated exactly
+//         (3) the points with the same radius run fastest
+func QuadPointsDisk(nRadial, nAngular int) (pts [][]float64) {
+	if nRadial < 1 || nAngular < 1 {
+		chk.Panic("number of points along radius and angle must be at least 1. nRadial=%d and nAngular=%d are invalid\n", nRadial, nAngular)
+	}
+	xr, wr := num.GaussJacobiXW(0, 1, nRadial) // (1+ξ) is the Jacobian r with r = (1+ξ)/2
+	dθ := 2.0 * math.Pi / float64(nAngular)
+	pts = make([][]float64, 0, nRadial*nAngular)
+	for i := 0; i < nRadial; i++ {
+		r := (1.0 + xr[i]) / 2.0
+		for j := 0; j < nAngular; j++ {
+			θ := float64(j) * dθ
+			pts = append(pts, []float64{r * math.Cos(θ), r * math.Sin(θ), 0, wr[i] / 4.0 * dθ})
+		}
+	}
+	return
+}
+
+// QuadPointsSphereSurface generate quadrature points over the surface of the unit sphere by means
+// of the product of Gauss-Legendre points along z = cos(θ) and 2⋅n equally spaced points along the
+// azimuth φ (trapezoidal rule)
+//    n -- number of points along z; the total number of points is 2⋅n²
+//   NOTE: (1) the sum of weights is 4π; i.e. the area of the surface of the unit sphere
+//         (2) spherical harmonics (polynomials in x, y, z) of degree up to 2⋅n-1 are integrated
+//             exactly
+//         (3) the points with the same z run fastest
+func QuadPointsSphereSurface(n int) (pts [][]float64) {
+	if n < 1 {
+		chk.Panic("number of points along z must be at least 1. n=%d is invalid\n", n)
+	}
+	xz, wz := quadPoints1d("legendre", n)
+	nφ := 2 * n
+	dφ := 2.0 * math.Pi / float64(nφ)
+	pts = make([][]float64, 0, n*nφ)
+	for i := 0; i < n; i++ {
+		z := xz[i]
+		ρ := math.Sqrt(1.0 - z*z)
+		for j := 0; j < nφ; j++ {
+			φ := float64(j) * dφ
+			pts = append(pts, []float64{ρ * math.Cos(φ), ρ * math.Sin(φ), z, wz[i] * dφ})
+		}
+	}
+	return
+}
+
+// QuadPointsGaussTensor generate quadrature points by means of the tensor product of 1D rules
+// with possibly different number of points along each direction
+//    rule    -- "legendre", "lobatto", "radauleft", "radauright", "newtoncotes" or "newtoncotesopen"
+//    nPerDim -- number of points along each direction [ndim]; e.g. {4, 2} for 4 points along
+//               x and 2 points along y. The total number of points is the product of all nPerDim
+func QuadPointsGaussTensor(rule string, nPerDim []int) (pts [][]float64) {
+	rules := make([]string, len(nPerDim))
+	for d := range rules {
+		rules[d] = rule
+	}
+	return QuadPointsMixedTensor(rules, nPerDim)
+}
+
+// QuadPointsMixedTensor generate quadrature points by means of the tensor product of 1D rules of
+// possibly different families along each direction; e.g. Gauss-Legendre in-plane and Gauss-Lobatto
+// through the thickness of shells to have points on the top and bottom faces
+//   rules   -- 1D rule along each direction [ndim]; the options are the same as in QuadPointsGaussTensor
+//   nPerDim -- number of points along each direction [ndim]
+func QuadPointsMixedTensor(rules []string, nPerDim []int) (pts [][]float64) {
+	if len(rules) != len(nPerDim) {
+		chk.Panic("number of rules (%d) must be equal to the number of directions (%d)\n", len(rules), len(nPerDim))
+	}
+	X := make([][]float64, len(nPerDim))
+	W := make([][]float64, len(nPerDim))
+	for d, n := range nPerDim {
+		X[d], W[d] = quadPoints1d(rules[d], n)
+	}
+	return quadPointsTensor(X, W)
+}
+
+// QuadPointsGaussTensorOrdered generate quadrature points by means of the tensor product of 1D rules
+// with a given ordering of points. See QuadPointsGaussTensor
+//    ordering -- "firstfastest" (or "") for the first index running fastest; i.e. the points are
+//                ordered as (i,j,k) with i fastest, then j, then k (default in QuadPointsGaussTensor)
+//                "lastfastest" for the lexicographic ordering; i.e. k fastest, then j, then i
+func QuadPointsGaussTensorOrdered(rule string, nPerDim []int, ordering string) (pts [][]float64) {
+	pts = QuadPointsGaussTensor(rule, nPerDim)
+	switch ordering {
+	case "", "firstfastest":
+		return
+	case "lastfastest":
+		ndim := len(nPerDim)
+		res := make([][]float64, len(pts))
+		pos := make([]int, ndim) // position along each direction of point m with first index fastest
+		for m := range pts {
+			lex := 0
+			for d := 0; d < ndim; d++ {
+				lex = lex*nPerDim[d] + pos[d]
+			}
+			res[lex] = pts[m]
+			for d := 0; d < ndim; d++ {
+				pos[d]++
+				if pos[d] < nPerDim[d] {
+					break
+				}
+				pos[d] = 0
+			}
+		}
+		return res
+	}
+	chk.Panic("ordering %q is invalid. options are \"firstfastest\" and \"lastfastest\"\n", ordering)
+	return
+}
+
+// QuadPointsGaussTensorND generate quadrature points by means of the tensor product of 1D rules
+// over the hypercube [-1,1]^ndim with any number of dimensions; e.g. ndim=4
+//    rule    -- "legendre", "lobatto", "radauleft", "radauright", "newtoncotes" or "newtoncotesopen"
+//    nPerDim -- number of points along each direction [ndim]; ndim = len(nPerDim) ≥ 1
+//   Output:
+//    X -- coordinates [npts][ndim] where npts is the product of all nPerDim
+//    W -- weights [npts]; i.e. the products of the 1D weights
+//   NOTE: the first coordinate runs fastest, as in QuadPointsGaussTensor. The output has the
+//         same format as QuadPointsSmolyak since the points cannot be stored as r,s,t,w
+func QuadPointsGaussTensorND(rule string, nPerDim []int) (X [][]float64, W []float64) {
+	ndim := len(nPerDim)
+	if ndim < 1 {
+		chk.Panic("space dimension of tensor-product rule must be at least 1. ndim=%d is invalid\n", ndim)
+	}
+	x := make([][]float64, ndim)
+	w := make([][]float64, ndim)
+	npts := 1
+	for d, n := range nPerDim {
+		x[d], w[d] = quadPoints1d(rule, n)
+		npts *= n
+	}
+	X = make([][]float64, npts)
+	W = make([]float64, npts)
+	pos := make([]int, ndim) // position within each 1D rule
+	for m := 0; m < npts; m++ {
+		X[m] = make([]float64, ndim)
+		W[m] = 1
+		for d := 0; d < ndim; d++ {
+			X[m][d] = x[d][pos[d]]
+			W[m] *= w[d][pos[d]]
+		}
+		for d := 0; d < ndim; d++ {
+			pos[d]++
+			if pos[d] < nPerDim[d] {
+				break
+			}
+			pos[d] = 0
+		}
+	}
+	return
+}
+
+// QuadPointsComposite generates quadrature points by subdividing the reference cell [-1,1]^ndim
+// into nCellsPerDim^ndim equal subcells and applying a tensor-product rule within each subcell
+//    rule         -- "legendre", "lobatto", "radauleft", "radauright", "newtoncotes" or "newtoncotesopen"
+//    nptsPerCell  -- number of points in each subcell; e.g. 9 for 2D (3 points along each direction)
+//    nCellsPerDim -- number of subcells along each direction
+//   NOTE: the total number of points is nptsPerCell * nCellsPerDim^ndim. With "lobatto",
+//         points on the boundaries between subcells are repeated
+func QuadPointsComposite(rule string, ndim, nptsPerCell, nCellsPerDim int) (pts [][]float64) {
+	if nCellsPerDim < 1 {
+		chk.Panic("number of subcells along each direction must be at least 1. nCellsPerDim=%d is invalid\n", nCellsPerDim)
+	}
+	n1d := quadPointsN1d(ndim, nptsPerCell)
+	x, w := quadPoints1d(rule, n1d)
+	h := 2.0 / float64(nCellsPerDim) // length of each subcell
+	xc := make([]float64, n1d*nCellsPerDim)
+	wc := make([]float64, n1d*nCellsPerDim)
+	for k := 0; k < nCellsPerDim; k++ {
+		xm := -1.0 + h*(float64(k)+0.5) // centre of subcell
+		for i := 0; i < n1d; i++ {
+			xc[i+n1d*k] = xm + 0.5*h*x[i]
+			wc[i+n1d*k] = 0.5 * h * w[i] // weight times the Jacobian of the subcell mapping
+		}
+	}
+	X := make([][]float64, ndim)
+	W := make([][]float64, ndim)
+	for d := 0; d < ndim; d++ {
+		X[d], W[d] = xc, wc
+	}
+	return quadPointsTensor(X, W)
+}
+
+// QuadPointsTensorProduct generates quadrature points by means of the Cartesian product of two
+// sets of points; e.g. a triangle set and a segment set to obtain a wedge set
+//   Input:
+//     a, ndimA -- first set of points [nptsA][4] and its space dimension
+//     b, ndimB -- second set of points [nptsB][4] and its space dimension
+//   Output:
+//     pts -- points [nptsA*nptsB][4] with coordinates (a..., b...) and weights wa⋅wb. The points
+//            of the first set run fastest. NOTE: ndimA + ndimB must be ≤ 3
+func QuadPointsTensorProduct(a [][]float64, ndimA int, b [][]float64, ndimB int) (pts [][]float64) {
+	if ndimA < 1 || ndimB < 1 || ndimA+ndimB > 3 {
+		chk.Panic("ndimA=%d and ndimB=%d are invalid. ndimA+ndimB must be 2 or 3\n", ndimA, ndimB)
+	}
+	pts = make([][]float64, len(a)*len(b))
+	for j, q := range b {
+		for i, p := range a {
+			x := make([]float64, 4)
+			copy(x, p[:ndimA])
+			copy(x[ndimA:], q[:ndimB])
+			x[3] = p[3] * q[3]
+			pts[i+len(a)*j] = x
+		}
+	}
+	return
+}
+
+// QuadPointsGaussDegree returns the polynomial degree integrated exactly by a tensor-product rule
+//    rule    -- "legendre", "lobatto", "radauleft", "radauright", "newtoncotes" or "newtoncotesopen"
+//    nPerDim -- number of points along each direction [ndim]
+//   NOTE: the degree is the minimum among all directions; i.e. all monomials with total degree
+//         less than or equal to the returned value are integrated exactly
+func QuadPointsGaussDegree(rule string, nPerDim []int) (degree int) {
+	for d, n := range nPerDim {
+		var deg int
+		switch rule {
+		case "legendre":
+			deg = 2*n - 1
+		case "lobatto":
+			deg = 2*n - 3
+		case "radauleft", "radauright":
+			deg = 2*n - 2
+		case "newtoncotes", "newtoncotesopen":
+			deg = n - 1 + n%2
+		default:
+			chk.Panic("cannot compute degree of rule %q\n", rule)
+		}
+		if d == 0 || deg < degree {
+			degree = deg
+		}
+	}
+	return
+}
+
+// quadPoints1dCache holds 1D positions and weights already computed by quadPoints1d. The values
+// are [2][]float64{x, w}. See SetCacheLimit
+var quadPoints1dCache = newQuadCache(DefaultCacheLimit)
+
+// quadPoints1dKey is the key to quadPoints1dCache
+type quadPoints1dKey struct {
+	rule string // "legendre", "lobatto", "radauleft", "radauright", "newtoncotes" or "newtoncotesopen"
+	n    int    // number of points
+}
+
+// NumCachedAbscissae returns the number of 1D sets of positions and weights in the cache shared by
+// all tensor-product rules; e.g. QuadPointsGaussLegendre(1, 3) and QuadPointsGaussLegendre(3, 27)
+// share the same 1D positions and weights
+func NumCachedAbscissae() int {
+	quadPoints1dCache.Lock()
+	defer quadPoints1dCache.Unlock()
+	return quadPoints1dCache.order.Len()
+}
+
+// quadPoints1d returns (cached) 1D positions and weights over [-1,1]
+//   rule -- "legendre", "lobatto", "radauleft", "radauright", "newtoncotes" or "newtoncotesopen"
+//   n    -- number of points
+//   NOTE: the returned slices are shared and must not be modified
+func quadPoints1d(rule string, n int) (x, w []float64) {
+	quadPoints1dCache.Lock()
+	defer quadPoints1dCache.Unlock()
+	key := quadPoints1dKey{rule, n}
+	if xw, ok := quadPoints1dCache.get(key); ok {
+		return xw.([2][]float64)[0], xw.([2][]float64)[1]
+	}
+	switch rule {
+	case "legendre":
+		x, w = num.GaussLegendreXW(-1, 1, n)
+	case "lobatto":
+		x, w = num.GaussLobattoXW(-1, 1, n)
+	case "radauleft":
+		x, w = num.GaussRadauXW(-1, 1, n, false)
+	case "radauright":
+		x, w = num.GaussRadauXW(-1, 1, n, true)
+	case "newtoncotes":
+		x, w = quadPointsNewtonCotes1d(n)
+	case "newtoncotesopen":
+		x, w = quadPointsNewtonCotesOpen1d(n)
+	default:
+		chk.Panic("cannot compute 1D quadrature points for rule %q\n", rule)
+	}
+	quadPoints1dCache.put(key, [2][]float64{x, w})
+	return
+}
+
+// quadPointsNewtonCotes1d returns the positions and weights of the closed Newton-Cotes rule over [-1,1]
+//   n -- number of points. 2 ≤ n ≤ 7
+func quadPointsNewtonCotes1d(n int) (x, w []float64) {
+	var c []float64 // weights over [0,1] times den
+	var den float64
+	switch n {
+	case 2:
+		c, den = []float64{1, 1}, 2 // trapezoidal rule
+	case 3:
+		c, den = []float64{1, 4, 1}, 6 // Simpson's rule
+	case 4:
+		c, den = []float64{1, 3, 3, 1}, 8 // Simpson's 3/8 rule
+	case 5:
+		c, den = []float64{7, 32, 12, 32, 7}, 90 // Boole's rule
+	case 6:
+		c, den = []float64{19, 75, 50, 50, 75, 19}, 288
+	case 7:
+		c, den = []float64{41, 216, 27, 272, 27, 216, 41}, 840
+	default:
+		chk.Panic("number of points for Newton-Cotes quadrature must be in [2, 7]. n=%d is invalid\n", n)
+	}
+	x = utl.LinSpace(-1, 1, n)
+	w = make([]float64, n)
+	for i := 0; i < n; i++ {
+		w[i] = 2.0 * c[i] / den
+	}
+	return
+}
+
+// quadPointsNewtonCotesOpen1d returns the positions and weights of the open Newton-Cotes rule over [-1,1]
+//   n -- number of points. 1 ≤ n ≤ 5
+func quadPointsNewtonCotesOpen1d(n int) (x, w []float64) {
+	var c []float64 // weights over [0,1] times den
+	var den float64
+	switch n {
+	case 1:
+		c, den = []float64{1}, 1 // midpoint rule
+	case 2:
+		c, den = []float64{1, 1}, 2
+	case 3:
+		c, den = []float64{2, -1, 2}, 3 // Milne's rule
+	case 4:
+		c, den = []float64{11, 1, 1, 11}, 24
+	case 5:
+		c, den = []float64{11, -14, 26, -14, 11}, 20
+	default:
+		chk.Panic("number of points for open Newton-Cotes quadrature must be in [1, 5]. n=%d is invalid\n", n)
+	}
+	x = make([]float64, n)
+	w = make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] = -1.0 + 2.0*float64(i+1)/float64(n+1)
+		w[i] = 2.0 * c[i] / den
+	}
+	return
+}
+
+// quadPointsN1d computes the number of points along each direction of a tensor-product rule
+// NOTE: npts must be equal to n1d^ndim; otherwise a panic will occur
+func quadPointsN1d(ndim, npts int) (n1d int) {
+	n1d = int(math.Floor(math.Pow(float64(npts), 1.0/float64(ndim)) + 0.5))
+	n := 1
+	for i := 0; i < ndim; i++ {
+		n *= n1d
+	}
+	if n != npts {
+		chk.Panic("npts=%d cannot be represented by a tensor-product rule with ndim=%d. the closest number is n1d^ndim = %d^%d = %d\n", npts, ndim, n1d, ndim, n)
+	}
+	return
+}
+
+// quadPointsTensor generates quadrature points by means of the tensor product of 1D rules
+//    X and W -- 1D positions and weights along each direction [ndim][n1d]
+func quadPointsTensor(X, W [][]float64) (pts [][]float64) {
+	ndim := len(X)
 	switch ndim {
 	case 1:
-		for i := 0; i < npts; i++ {
-			pts[i] = []float64{x[i], 0, 0, w[i]}
+		pts = make([][]float64, len(X[0]))
+		for i := 0; i < len(X[0]); i++ {
+			pts[i] = []float64{X[0][i], 0, 0, W[0][i]}
 		}
 	case 2:
-		for j := 0; j < n1d; j++ {
-			for i := 0; i < n1d; i++ {
-				m := i + n1d*j
-				pts[m] = []float64{x[i], x[j], 0, w[i] * w[j]}
+		n0, n1 := len(X[0]), len(X[1])
+		pts = make([][]float64, n0*n1)
+		for j := 0; j < n1; j++ {
+			for i := 0; i < n0; i++ {
+				m := i + n0*j
+				pts[m] = []float64{X[0][i], X[1][j], 0, W[0][i] * W[1][j]}
 			}
 		}
 	case 3:
-		for k := 0; k < n1d; k++ {
-			for j := 0; j < n1d; j++ {
-				for i := 0; i < n1d; i++ {
-					m := i + n1d*j + (n1d*n1d)*k
-					pts[m] = []float64{x[i], x[j], x[k], w[i] * w[j] * w[k]}
+		n0, n1, n2 := len(X[0]), len(X[1]), len(X[2])
+		pts = make([][]float64, n0*n1*n2)
+		for k := 0; k < n2; k++ {
+			for j := 0; j < n1; j++ {
+				for i := 0; i < n0; i++ {
+					m := i + n0*j + (n0*n1)*k
+					pts[m] = []float64{X[0][i], X[1][j], X[2][k], W[0][i] * W[1][j] * W[2][k]}
 				}
 			}
 		}
+	default:
+		chk.Panic("ndim=%d is invalid. ndim must be 1, 2, or 3; use QuadPointsGaussTensorND for other dimensions\n", ndim)
 	}
 	return
 }
 
 // QuadPointsWilson5 generates 5 integration points according to Wilson's Appendix G-7 formulae
-//    w0input  -- if w0input > 0, use this value instead of default w0=8/3 (corner)
+//    w0input  -- if w0input > 0, use this value instead of default w0=8/3 (corner);
+//                w0input must be smaller than 4 because wa = 1 - w0/4 must be positive;
+//                w0input is ignored (and not validated) if p4stable is true
 //    p4stable -- if true, use w0=0.004 and wa=0.999 to mimic 4-point rule
+//   NOTE: polynomials of degree 3 are integrated exactly; however, with p4stable,
+//         only polynomials of degree 1 are integrated exactly
 func QuadPointsWilson5(w0input float64, p4stable bool) (pts [][]float64) {
 	w0 := 8.0 / 3.0
 	wa := 1.0 / 3.0
 	a := 1.0
-	if w0input > 0 {
-		w0 = w0input
-		wa = 1.0 - w0/4.0
-		a = math.Sqrt(1.0 / (3.0 * wa))
-	}
 	if p4stable {
 		w0 = 0.004
 		wa = 0.999
 		a = 0.5776391
+	} else if w0input > 0 {
+		if w0input >= 4 {
+			chk.Panic("w0 must be in (0,4) for Wilson's 5-point rule. w0 = %g is invalid\n", w0input)
+		}
+		w0 = w0input
+		wa = 1.0 - w0/4.0
+		a = math.Sqrt(1.0 / (3.0 * wa))
 	}
 	return [][]float64{
 		{-a, -a, 0, wa},
@@ -70,13 +927,45 @@ func QuadPointsWilson5(w0input float64, p4stable bool) (pts [][]float64) {
 	}
 }
 
+// Wilson5SweepResult holds the result of integrating a function with Wilson's 5-point rule with a
+// given w0. See QuadPointsWilson5Sweep
+type Wilson5SweepResult struct {
+	W0    float64 // weight of the central point
+	Value float64 // integral computed with the 5-point rule
+	Error float64 // absolute difference between Value and the reference integral
+}
+
+// QuadPointsWilson5Sweep integrates f over the reference square with Wilson's 5-point rule for each
+// w0 in w0values (see QuadPointsWilson5) and computes the errors with respect to the integral given
+// by the Gauss-Legendre rule with 10 points along each direction; e.g. to select w0 for a family
+// of integrands
+//   NOTE: all w0values must be in (0,4)
+func QuadPointsWilson5Sweep(w0values []float64, f fun.Sv) (res []Wilson5SweepResult) {
+	reference := QuadPointsIntegrate(QuadPointsGaussLegendre(2, 100), 2, f)
+	res = make([]Wilson5SweepResult, len(w0values))
+	for i, w0 := range w0values {
+		if w0 <= 0 || w0 >= 4 {
+			chk.Panic("w0 must be in (0,4) for Wilson's 5-point rule. w0 = %g is invalid\n", w0)
+		}
+		value := QuadPointsIntegrate(QuadPointsWilson5(w0, false), 2, f)
+		res[i] = Wilson5SweepResult{W0: w0, Value: value, Error: math.Abs(value - reference)}
+	}
+	return
+}
+
 // QuadPointsWilson8 generates 8 integration points according to Wilson's Appendix G-7 formulae
-//    wbinput -- if wbinput > 0, use this value instead of default wb=40/49
+//    wbinput -- if wbinput > 0, use this value instead of default wb=40/49; wbinput must be
+//               smaller than 1 because wa = 1 - wb must be positive
+//   NOTE: polynomials of degree 5 are integrated exactly with the default wb; otherwise,
+//         only polynomials of degree 3 are integrated exactly
 func QuadPointsWilson8(wbinput float64) (pts [][]float64) {
 	a := math.Sqrt(7.0 / 9.0)
 	b := math.Sqrt(7.0 / 15.0)
 	wa := 9.0 / 49.0
 	wb := 40.0 / 49.0
+	if wbinput >= 1 {
+		chk.Panic("wb must be in (0,1) for Wilson's 8-point rule. wb = %g is invalid\n", wbinput)
+	}
 	if wbinput > 0 {
 		wb = wbinput
 		wa = 1.0 - wb
@@ -97,21 +986,27 @@ func QuadPointsWilson8(wbinput float64) (pts [][]float64) {
 }
 
 // QuadPointsWilson9 computes the 9-points for hexahedra according to Wilson's Appendix G-7 formulae
-//    w0input  -- if w0input > 0, use this value instead of default w0=16/3 (corner)
+//    w0input  -- if w0input > 0, use this value instead of default w0=16/3 (corner);
+//                w0input must be smaller than 8 because wa = 1 - w0/8 must be positive;
+//                w0input is ignored (and not validated) if p8stable is true
 //    p8stable -- if true, use w0=0.008 and wa=0.999 to mimic 8-point rule
+//   NOTE: polynomials of degree 3 are integrated exactly; however, with p8stable,
+//         only polynomials of degree 1 are integrated exactly
 func QuadPointsWilson9(w0input float64, p8stable bool) (pts [][]float64) {
 	w0 := 16.0 / 3.0
 	wa := 1.0 / 3.0
 	a := 1.0
-	if w0input > 0 {
-		w0 = w0input
-		wa = 1.0 - w0/8.0
-		a = math.Sqrt(1.0 / (3.0 * wa))
-	}
 	if p8stable {
 		w0 = 0.008
 		wa = 0.999
 		a = 0.5776391
+	} else if w0input > 0 {
+		if w0input >= 8 {
+			chk.Panic("w0 must be in (0,8) for Wilson's 9-point rule. w0 = %g is invalid\n", w0input)
+		}
+		w0 = w0input
+		wa = 1.0 - w0/8.0
+		a = math.Sqrt(1.0 / (3.0 * wa))
 	}
 	return [][]float64{
 		{-a, -a, -a, wa},
@@ -126,33 +1021,58 @@ func QuadPointsWilson9(w0input float64, p8stable bool) (pts [][]float64) {
 	}
 }
 
-// QuadPointDraw draws quadrature point within standard rectangle or box
-//   dx -- can be used to displace box; may be nil
+// QuadPointDraw draws quadrature point within standard segment, rectangle or box
+//   triOrTet -- draws the reference triangle (ndim=2) or tetrahedron (ndim=3) instead
+//   dx       -- can be used to displace segment, rectangle or box; may be nil
+//   NOTE: see IntPointsSet.Draw for other kinds of cells
 func QuadPointDraw(pts [][]float64, ndim int, triOrTet bool, dx []float64, args *plt.A) {
+	cellKind := KindLin
+	switch {
+	case ndim == 2 && triOrTet:
+		cellKind = KindTri
+	case ndim == 2:
+		cellKind = KindQua
+	case ndim == 3 && triOrTet:
+		cellKind = KindTet
+	case ndim == 3:
+		cellKind = KindHex
+	}
+	quadPointDrawCell(pts, cellKind, dx, args)
+}
+
+// quadPointDrawCell draws quadrature points within the reference cell of a given kind
+func quadPointDrawCell(pts [][]float64, cellKind int, dx []float64, args *plt.A) {
 	if args == nil {
 		args = &plt.A{C: "r", M: "*", Mec: "r", NoClip: true}
 	}
+	ndim := kindNdim(cellKind)
 	if len(dx) != ndim {
 		dx = []float64{0, 0, 0}
 	}
-	argsPoly := &plt.A{Fc: "none", Ec: "#2645cb", Closed: true, NoClip: true}
-	if ndim == 2 {
-		if triOrTet {
-			plt.Polyline([][]float64{
-				{dx[0], dx[1]}, {dx[0] + 1, dx[1]}, {dx[0] + 0, dx[1] + 1},
-			}, argsPoly)
-		} else {
-			plt.Polyline([][]float64{
-				{dx[0] - 1, dx[1] - 1}, {dx[0] + 1, dx[1] - 1}, {dx[0] + 1, dx[1] + 1}, {dx[0] - 1, dx[1] + 1},
-			}, argsPoly)
+	X, edges := quadPointOutline(cellKind)
+	if ndim == 1 {
+		plt.Plot([]float64{dx[0] - 1, dx[0] + 1}, []float64{0, 0}, &plt.A{C: "#2645cb", M: "|", NoClip: true})
+		for _, p := range pts {
+			plt.PlotOne(dx[0]+p[0], 0, args)
+		}
+	} else if ndim == 2 {
+		polygon := make([][]float64, len(X))
+		for i, x := range X {
+			polygon[i] = []float64{dx[0] + x[0], dx[1] + x[1]}
 		}
+		plt.Polyline(polygon, &plt.A{Fc: "none", Ec: "#2645cb", Closed: true, NoClip: true})
 		for _, p := range pts {
 			plt.PlotOne(dx[0]+p[0], dx[1]+p[1], args)
 		}
 	} else {
-		if triOrTet {
-		} else {
+		if cellKind == KindHex {
 			plt.Box(dx[0]-1, dx[0]+1, dx[1]-1, dx[1]+1, dx[2]-1, dx[2]+1, &plt.A{Wire: true, Ls: "-", Ec: "#2645cb", Lw: 3})
+		} else {
+			for _, e := range edges {
+				a, b := X[e[0]], X[e[1]]
+				plt.Plot3dLine([]float64{dx[0] + a[0], dx[0] + b[0]}, []float64{dx[1] + a[1], dx[1] + b[1]},
+					[]float64{dx[2] + a[2], dx[2] + b[2]}, &plt.A{C: "#2645cb", Lw: 3})
+			}
 		}
 		for _, p := range pts {
 			plt.Plot3dPoint(dx[0]+p[0], dx[1]+p[1], dx[2]+p[2], args)
@@ -160,40 +1080,397 @@ func QuadPointDraw(pts [][]float64, ndim int, triOrTet bool, dx []float64, args
 	}
 }
 
+// quadPointOutline returns the vertices and edges of the reference cell of a given kind
+//   X     -- coordinates of vertices [nverts][ndim]. For ndim = 2, the vertices are in
+//            counter-clockwise order; i.e. they form the outline polygon
+//   edges -- indices of the two vertices of each edge [nedges][2]
+func quadPointOutline(cellKind int) (X [][]float64, edges [][2]int) {
+	switch cellKind {
+	case KindLin:
+		return [][]float64{{-1}, {1}}, [][2]int{{0, 1}}
+	case KindTri:
+		return [][]float64{{0, 0}, {1, 0}, {0, 1}}, [][2]int{{0, 1}, {1, 2}, {2, 0}}
+	case KindQua:
+		return [][]float64{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}}, [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 0}}
+	case KindTet:
+		return [][]float64{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}, {0, 0, 1}},
+			[][2]int{{0, 1}, {1, 2}, {2, 0}, {0, 3}, {1, 3}, {2, 3}}
+	case KindHex:
+		return [][]float64{
+			{-1, -1, -1}, {1, -1, -1}, {1, 1, -1}, {-1, 1, -1},
+			{-1, -1, 1}, {1, -1, 1}, {1, 1, 1}, {-1, 1, 1},
+		}, [][2]int{
+			{0, 1}, {1, 2}, {2, 3}, {3, 0},
+			{4, 5}, {5, 6}, {6, 7}, {7, 4},
+			{0, 4}, {1, 5}, {2, 6}, {3, 7},
+		}
+	case KindWed:
+		return [][]float64{{0, 0, -1}, {1, 0, -1}, {0, 1, -1}, {0, 0, 1}, {1, 0, 1}, {0, 1, 1}},
+			[][2]int{{0, 1}, {1, 2}, {2, 0}, {3, 4}, {4, 5}, {5, 3}, {0, 3}, {1, 4}, {2, 5}}
+	case KindPyr:
+		return [][]float64{{-1, -1, 0}, {1, -1, 0}, {1, 1, 0}, {-1, 1, 0}, {0, 0, 1}},
+			[][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 0}, {0, 4}, {1, 4}, {2, 4}, {3, 4}}
+	}
+	chk.Panic("cellKind = %d is invalid\n", cellKind)
+	return
+}
+
+// QuadPointDrawWeighted draws quadrature points within standard segment, rectangle or box with
+// marker sizes proportional to the absolute value of weights
+//   dx           -- can be used to displace segment, rectangle or box; may be nil
+//   msMin, msMax -- minimum and maximum marker sizes; e.g. 2 and 20
+//   NOTE: points with negative weights are drawn in blue; the others are drawn in red
+func QuadPointDrawWeighted(pts [][]float64, ndim int, triOrTet bool, dx []float64, msMin, msMax int) {
+	QuadPointDraw(nil, ndim, triOrTet, dx, nil)
+	if len(dx) != ndim {
+		dx = []float64{0, 0, 0}
+	}
+	sizes := quadPointMarkerSizes(pts, msMin, msMax)
+	for i, p := range pts {
+		args := &plt.A{C: "r", M: "o", Ms: sizes[i], Mec: "r", NoClip: true}
+		if p[3] < 0 {
+			args.C, args.Mec = "b", "b"
+		}
+		switch ndim {
+		case 1:
+			plt.PlotOne(dx[0]+p[0], 0, args)
+		case 2:
+			plt.PlotOne(dx[0]+p[0], dx[1]+p[1], args)
+		default:
+			plt.Plot3dPoint(dx[0]+p[0], dx[1]+p[1], dx[2]+p[2], args)
+		}
+	}
+}
+
+// quadPointMarkerSizes computes marker sizes proportional to |w| in [msMin, msMax]
+func quadPointMarkerSizes(pts [][]float64, msMin, msMax int) (sizes []int) {
+	wmax := 0.0
+	for _, p := range pts {
+		wmax = math.Max(wmax, math.Abs(p[3]))
+	}
+	sizes = make([]int, len(pts))
+	for i, p := range pts {
+		sizes[i] = msMax
+		if wmax > 0 {
+			sizes[i] = int(math.Floor(float64(msMax)*math.Abs(p[3])/wmax + 0.5))
+		}
+		if sizes[i] < msMin {
+			sizes[i] = msMin
+		}
+	}
+	return
+}
+
 /// map of integration points //////////////////////////////////////////////////////////////////////
 
 var (
-	// IntPoints holds integration points for all kinds of cells: lin,qua,hex,tri,tet
-	// It maps [cellKind] => [options][npts][4] where 4 means r,s,t,w
-	IntPoints map[int]map[string][][]float64
-
-	// DefaultIntPoints holds the default integration points for all cell types
-	// It maps [cellTypeIndex] => [npts][4] where 4 means r,s,t,w
-	// NOTE: the highest number of integration points is selected,
-	//       thus the default number may not be optimal.
-	DefaultIntPoints [][][]float64
+	// intPoints holds integration points for all kinds of cells. See GetIntPoints
+	intPoints map[int]map[string][][]float64
+
+	// defaultIntPoints holds the default integration points for all cell types. See GetDefaultIntPoints
+	defaultIntPoints [][][]float64
+
+	// intPointsDegree holds the polynomial degree integrated exactly by each set in intPoints
+	// It maps [cellKind] => [options] => degree
+	intPointsDegree map[int]map[string]int
+
+	// intPointsOnce guards the initialisation of the database of integration points
+	intPointsOnce sync.Once
 )
 
+// GetIntPoints returns the integration points for all kinds of cells: lin,qua,hex,tri,tet,wed,pyr
+// It maps [cellKind] => [options][npts][4] where 4 means r,s,t,w
+// NOTE: (1) the database is built on first use; this is safe under concurrent access
+//       (2) the sets are shared (e.g. by all Integrators) and must not be modified;
+//           use utl.Clone or IntPointsSet.Clone to obtain a private copy
+func GetIntPoints() map[int]map[string][][]float64 {
+	intPointsOnce.Do(intPointsInit)
+	return intPoints
+}
+
+// GetDefaultIntPoints returns the default integration points for all cell types
+// It maps [cellTypeIndex] => [npts][4] where 4 means r,s,t,w
+// NOTE: (1) the highest number of integration points is selected,
+//           thus the default number may not be optimal.
+//       (2) the database is built on first use. See GetIntPoints
+func GetDefaultIntPoints() [][][]float64 {
+	intPointsOnce.Do(intPointsInit)
+	return defaultIntPoints
+}
+
+// getIntPointsDegree returns the polynomial degrees of the sets in the database. See GetIntPoints
+func getIntPointsDegree() map[int]map[string]int {
+	intPointsOnce.Do(intPointsInit)
+	return intPointsDegree
+}
+
 // IntPointsFindSet finds set of integration points by cell kind and set name
+//   NOTE: Gauss-Legendre sets for lin, qua and hex (e.g. "legendre_7" for lin) which are not in
+//         the database are generated on demand (see QuadPointsGaussLegendre)
 func IntPointsFindSet(cellKind int, setName string) (P [][]float64) {
-	if cellKind < 0 || cellKind > KindNumMax {
-		chk.Panic("cellKind = %d is invalid\n", cellKind)
+	P, err := IntPointsGetSet(cellKind, setName)
+	if err != nil {
+		chk.Panic("%v", err)
 	}
-	db, ok := IntPoints[cellKind]
+	return
+}
+
+// IntPointsGetSet gets set of integration points by cell kind and set name; an error is returned
+// instead of a panic if the set is not available. See IntPointsFindSet
+//   NOTE: Gauss-Legendre sets for lin, qua and hex (e.g. "legendre_64" for hex) and Gauss-Kronrod
+//         sets for lin which are not in the database are generated on first request and cached;
+//         thus, the returned points are shared and must not be modified
+func IntPointsGetSet(cellKind int, setName string) (P [][]float64, err error) {
+	if cellKind < 0 || cellKind >= KindNumMax {
+		return nil, chk.Err("cellKind = %d is invalid\n", cellKind)
+	}
+	db, ok := GetIntPoints()[cellKind]
 	if !ok {
-		chk.Panic("integration points set for cellKind = %d is not implemented yet\n", cellKind)
+		return nil, chk.Err("integration points set for cellKind = %d is not implemented yet\n", cellKind)
+	}
+	if P, ok = db[setName]; ok {
+		return
+	}
+	intPointsGenerated.Lock()
+	defer intPointsGenerated.Unlock()
+	key := intPointsGeneratedKey{cellKind, setName}
+	if v, found := intPointsGenerated.get(key); found {
+		return v.([][]float64), nil
+	}
+	if P, _, ok = intPointsGenerate(cellKind, setName); !ok {
+		return nil, chk.Err("cannot find integration points set named = %q for cellKind = %d\n", setName, cellKind)
+	}
+	intPointsGenerated.put(key, P)
+	return
+}
+
+// IntPointsCrossCheck compares a tabulated set in the database of integration points with the same
+// set generated from its rule, regardless of the order of points; e.g. "legendre_9" for qua is
+// compared with QuadPointsGaussLegendre(2, 9). This guards against divergence of tabulated values
+//   tol -- tolerance for the coordinates and weights
+//   NOTE: only Gauss-Legendre sets for lin, qua and hex can be generated; thus, an error is returned
+//         for the other sets. Otherwise, the returned error describes the first discrepancy found
+func IntPointsCrossCheck(cellKind int, setName string, tol float64) (err error) {
+	if cellKind < 0 || cellKind >= KindNumMax {
+		return chk.Err("cellKind = %d is invalid\n", cellKind)
+	}
+	tabulated, ok := GetIntPoints()[cellKind][setName]
+	if !ok {
+		return chk.Err("integration points set named = %q for cellKind = %d is not in the database\n", setName, cellKind)
+	}
+	generated, _, ok := intPointsGenerate(cellKind, setName)
+	if !ok {
+		return chk.Err("integration points set named = %q for cellKind = %d cannot be generated\n", setName, cellKind)
+	}
+	err = quadPointsDiscrepancy(tabulated, generated, tol)
+	if err != nil {
+		return chk.Err("tabulated and generated sets named = %q for cellKind = %d differ:\n%v", setName, cellKind, err)
+	}
+	return
+}
+
+// quadPointsDiscrepancy compares two sets of quadrature points as QuadPointsMatch does, but returns
+// an error describing the first point of a without a match in b
+func quadPointsDiscrepancy(a, b [][]float64, tol float64) (err error) {
+	if len(a) != len(b) {
+		return chk.Err("numbers of points are different: %d != %d\n", len(a), len(b))
+	}
+	used := make([]bool, len(b))
+	for i, p := range a {
+		jmin, dmin := -1, math.Inf(1)
+		for j, q := range b {
+			if used[j] {
+				continue
+			}
+			d := 0.0
+			for k := 0; k < 4; k++ {
+				d = math.Max(d, math.Abs(p[k]-q[k]))
+			}
+			if d < dmin {
+				jmin, dmin = j, d
+			}
+		}
+		if dmin > tol {
+			return chk.Err("point %d = %v has no match; the closest point is %v with difference %g > tol = %g\n", i, p, b[jmin], dmin, tol)
+		}
+		used[jmin] = true
 	}
-	if P, ok = db[setName]; !ok {
-		chk.Panic("cannot find integration points set named = %q for cellKind = %d\n", setName, cellKind)
+	return
+}
+
+// intPointsGenerated holds the sets generated on demand by IntPointsGetSet. The values are
+// [][]float64. See SetCacheLimit
+var intPointsGenerated = newQuadCache(DefaultCacheLimit)
+
+// intPointsGeneratedKey is the key to intPointsGenerated
+type intPointsGeneratedKey struct {
+	cellKind int    // kind of cell
+	setName  string // name of set; e.g. "legendre_64"
+}
+
+// IntPointsFindByNpts returns the names of the sets of integration points in the database with
+// a given number of points. The names are sorted in ascending order
+//   NOTE: Gauss-Legendre sets for lin, qua and hex which are not in the database are not included
+func IntPointsFindByNpts(cellKind, npts int) (setNames []string) {
+	for name, P := range GetIntPoints()[cellKind] {
+		if len(P) == npts {
+			setNames = append(setNames, name)
+		}
 	}
+	sort.Strings(setNames)
 	return
 }
 
-func init() {
+// IntPointsSetInfo holds the name and number of points of a set in the database of integration points
+type IntPointsSetInfo struct {
+	Name string // name of set; e.g. "legendre_4"
+	Npts int    // number of points
+}
+
+// IntPointsAvailable returns the names and number of points of all sets of integration points in
+// the database for a cell kind, including sets added with IntPointsRegister. The sets are sorted
+// by number of points and then by name
+//   NOTE: Gauss-Legendre sets for lin, qua and hex which are not in the database are not included
+func IntPointsAvailable(cellKind int) (sets []IntPointsSetInfo) {
+	for name, P := range GetIntPoints()[cellKind] {
+		sets = append(sets, IntPointsSetInfo{name, len(P)})
+	}
+	sort.Slice(sets, func(i, j int) bool {
+		if sets[i].Npts == sets[j].Npts {
+			return sets[i].Name < sets[j].Name
+		}
+		return sets[i].Npts < sets[j].Npts
+	})
+	return
+}
+
+// intPointsGenerate generates Gauss-Legendre sets for lin, qua and hex named as "legendre_npts"
+// and Gauss-Kronrod sets for lin named as "kronrod_npts"
+//   ok -- false if setName does not correspond to a valid generated set
+func intPointsGenerate(cellKind int, setName string) (P [][]float64, degree int, ok bool) {
+	if cellKind == KindLin && strings.HasPrefix(setName, "kronrod_") {
+		npts, err := strconv.Atoi(strings.TrimPrefix(setName, "kronrod_"))
+		if err != nil || npts < 3 || npts%2 == 0 {
+			return
+		}
+		n := (npts - 1) / 2
+		_, P = QuadPointsGaussKronrod(n)
+		return P, 3*n + 1 + n%2, true
+	}
+	var ndim int
+	switch cellKind {
+	case KindLin:
+		ndim = 1
+	case KindQua:
+		ndim = 2
+	case KindHex:
+		ndim = 3
+	default:
+		return
+	}
+	if !strings.HasPrefix(setName, "legendre_") {
+		return
+	}
+	npts, err := strconv.Atoi(strings.TrimPrefix(setName, "legendre_"))
+	if err != nil || npts < 1 {
+		return
+	}
+	n1d := int(math.Floor(math.Pow(float64(npts), 1.0/float64(ndim)) + 0.5))
+	if int(math.Pow(float64(n1d), float64(ndim))+0.5) != npts {
+		return
+	}
+	return QuadPointsGaussLegendre(ndim, npts), 2*n1d - 1, true
+}
+
+// IntPointsFindDegree returns the polynomial degree integrated exactly by a set of integration points
+//   NOTE: (1) all monomials with total degree less than or equal to the returned value are
+//             integrated exactly over the reference cell
+//         (2) a negative value means that not even constant functions are integrated exactly
+func IntPointsFindDegree(cellKind int, setName string) (degree int) {
+	IntPointsFindSet(cellKind, setName)
+	degree, ok := getIntPointsDegree()[cellKind][setName]
+	if _, inDb := GetIntPoints()[cellKind][setName]; !inDb {
+		_, degree, ok = intPointsGenerate(cellKind, setName)
+	}
+	if !ok {
+		chk.Panic("degree of integration points set named = %q for cellKind = %d is not available\n", setName, cellKind)
+	}
+	return
+}
+
+// intPointsMeta returns the source and the recommended use of the built-in sets of integration
+// points; empty strings are returned if the information is not available
+func intPointsMeta(cellKind int, setName string) (source, recommendedFor string) {
+	switch {
+	case strings.HasPrefix(setName, "legendre_") && (cellKind == KindLin || cellKind == KindQua || cellKind == KindHex):
+		return "Gauss-Legendre rule (tensor product along each direction)", "full integration of lin, qua and hex elements"
+	case strings.HasPrefix(setName, "kronrod_") && cellKind == KindLin:
+		return "Gauss-Kronrod extension of the Gauss-Legendre rule; see num.GaussKronrodXW", "estimation of integration errors"
+	case strings.HasPrefix(setName, "wilson5") && cellKind == KindQua:
+		return "Wilson's Appendix G-7 formulae (5-point rule)", "qua elements; the \"stable\" variant mimics the 4-point Gauss-Legendre rule"
+	case strings.HasPrefix(setName, "wilson8") && cellKind == KindQua:
+		return "Wilson's Appendix G-7 formulae (8-point rule)", "qua elements; integrates polynomials of degree 5 with 8 instead of 9 points"
+	case strings.HasPrefix(setName, "wilson9") && cellKind == KindHex:
+		return "Wilson's Appendix G-7 formulae (9-point rule)", "hex elements; the \"stable\" variant mimics the 8-point Gauss-Legendre rule"
+	case strings.HasPrefix(setName, "irons_") && cellKind == KindHex:
+		return "Irons BM (1971) Quadrature rules for brick based finite elements. International Journal for Numerical Methods in Engineering, 3(2):293-294", "hex elements with fewer points than Gauss-Legendre rules of the same degree"
+	case (setName == "internal_11" || setName == "internal_15") && cellKind == KindTet:
+		return "Keast P (1986) Moderate-degree tetrahedral quadrature formulas. Computer Methods in Applied Mechanics and Engineering, 55(3):339-348", "tet elements"
+	case cellKind == KindWed:
+		return "tensor product of a tri set and the Gauss-Legendre rule; see QuadPointsWedge", "wed elements"
+	case cellKind == KindPyr:
+		return "collapsed Gauss-Legendre and Gauss-Jacobi rules; see QuadPointsPyramid", "pyr elements"
+	}
+	return
+}
+
+// IntPointsSelectSet selects the set of integration points with the smallest number of points
+// which integrates exactly all polynomials of a given degree
+//   cellKind -- kind of cell; e.g. KindQua
+//   degree   -- required polynomial degree
+//   NOTE: (1) only sets in the database with known degree are considered; ties are resolved by
+//             selecting the set with positive weights and then by name
+//         (2) Wilson's sets are not considered because they are meant for special purposes
+//             such as reduced or stabilised integration
+//         (3) if no set is found for lin, qua or hex, a Gauss-Legendre set is generated
+func IntPointsSelectSet(cellKind int, degree int) (setName string, err error) {
+	db, ok := GetIntPoints()[cellKind]
+	if !ok {
+		return "", chk.Err("integration points set for cellKind = %d is not implemented yet\n", cellKind)
+	}
+	npts := 0
+	negative := false
+	for name, P := range db {
+		deg, ok := getIntPointsDegree()[cellKind][name]
+		if !ok || deg < degree || strings.HasPrefix(name, "wilson") {
+			continue
+		}
+		neg := QuadPointsHasNegativeWeights(P)
+		better := setName == "" || len(P) < npts
+		if len(P) == npts {
+			better = (negative && !neg) || (negative == neg && name < setName)
+		}
+		if better {
+			setName, npts, negative = name, len(P), neg
+		}
+	}
+	if setName != "" {
+		return
+	}
+	if cellKind == KindLin || cellKind == KindQua || cellKind == KindHex {
+		n1d := utl.Imax(1, (degree+2)/2) // 2⋅n1d-1 ≥ degree
+		ndim := kindNdim(cellKind)
+		return io.Sf("legendre_%d", int(math.Pow(float64(n1d), float64(ndim))+0.5)), nil
+	}
+	return "", chk.Err("there is no integration points set for cellKind = %d with degree ≥ %d\n", cellKind, degree)
+}
+
+// intPointsInit builds the database of integration points. See GetIntPoints
+func intPointsInit() {
 
 	// set integration points for "lin" kind
-	IntPoints = make(map[int]map[string][][]float64)
-	IntPoints[KindLin] = map[string][][]float64{
+	intPoints = make(map[int]map[string][][]float64)
+	intPoints[KindLin] = map[string][][]float64{
 		"legendre_1": {
 			{0, 0, 0, 2},
 		},
@@ -219,10 +1496,15 @@ func init() {
 			{+0.5384693101056831, 0, 0, 0.4786286704993665},
 			{+0.9061798459386640, 0, 0, 0.2369268850561891},
 		},
+		"legendre_6":  QuadPointsGaussLegendre(1, 6),
+		"legendre_7":  QuadPointsGaussLegendre(1, 7),
+		"legendre_8":  QuadPointsGaussLegendre(1, 8),
+		"legendre_9":  QuadPointsGaussLegendre(1, 9),
+		"legendre_10": QuadPointsGaussLegendre(1, 10),
 	}
 
 	// set integration points for "qua" kind
-	IntPoints[KindQua] = map[string][][]float64{
+	intPoints[KindQua] = map[string][][]float64{
 		"legendre_1": {
 			{0, 0, 0, 4},
 		},
@@ -254,7 +1536,7 @@ func init() {
 	SQ19by33 := math.Sqrt(19.0 / 33.0)
 
 	// set integration points for "hex" kind
-	IntPoints[KindHex] = map[string][][]float64{
+	intPoints[KindHex] = map[string][][]float64{
 		"legendre_8": {
 			{-0.5773502691896257, -0.5773502691896257, -0.5773502691896257, 1},
 			{+0.5773502691896257, -0.5773502691896257, -0.5773502691896257, 1},
@@ -322,8 +1604,11 @@ func init() {
 		},
 	}
 
+	// auxiliary constants
+	SQ15 := math.Sqrt(15.0)
+
 	// set integration points for "tri" kind
-	IntPoints[KindTri] = map[string][][]float64{
+	intPoints[KindTri] = map[string][][]float64{
 		"internal_1": {
 			{1.0 / 3.0, 1.0 / 3.0, 0, 1.0 / 2.0},
 		},
@@ -343,6 +1628,23 @@ func init() {
 			{3.0 / 5.0, 1.0 / 5.0, 0, +25.0 / 96.0},
 			{1.0 / 5.0, 3.0 / 5.0, 0, +25.0 / 96.0},
 		},
+		"internal_6": {
+			{0.4459484909159648, 0.4459484909159648, 0, 0.1116907948390055},
+			{0.1081030181680704, 0.4459484909159648, 0, 0.1116907948390055},
+			{0.4459484909159648, 0.1081030181680704, 0, 0.1116907948390055},
+			{0.0915762135097711, 0.0915762135097711, 0, 0.0549758718276612},
+			{0.8168475729804578, 0.0915762135097711, 0, 0.0549758718276612},
+			{0.0915762135097711, 0.8168475729804578, 0, 0.0549758718276612},
+		},
+		"internal_7": {
+			{1.0 / 3.0, 1.0 / 3.0, 0, 9.0 / 80.0},
+			{(6.0 - SQ15) / 21.0, (6.0 - SQ15) / 21.0, 0, (155.0 - SQ15) / 2400.0},
+			{(9.0 + 2.0*SQ15) / 21.0, (6.0 - SQ15) / 21.0, 0, (155.0 - SQ15) / 2400.0},
+			{(6.0 - SQ15) / 21.0, (9.0 + 2.0*SQ15) / 21.0, 0, (155.0 - SQ15) / 2400.0},
+			{(6.0 + SQ15) / 21.0, (6.0 + SQ15) / 21.0, 0, (155.0 + SQ15) / 2400.0},
+			{(9.0 - 2.0*SQ15) / 21.0, (6.0 + SQ15) / 21.0, 0, (155.0 + SQ15) / 2400.0},
+			{(6.0 + SQ15) / 21.0, (9.0 - 2.0*SQ15) / 21.0, 0, (155.0 + SQ15) / 2400.0},
+		},
 		"internal_12": {
 			{0.873821971016996, 0.063089014491502, 0, 0.0254224531851035},
 			{0.063089014491502, 0.873821971016996, 0, 0.0254224531851035},
@@ -357,6 +1659,21 @@ func init() {
 			{0.636502499121398, 0.053145049844817, 0, 0.041425537809187},
 			{0.636502499121398, 0.310352451033784, 0, 0.041425537809187},
 		},
+		"internal_13": {
+			{0.3333333333333333, 0.3333333333333333, 0, -0.0747850222338780},
+			{0.2603459660790334, 0.2603459660790334, 0, +0.0878076287166289},
+			{0.4793080678419331, 0.2603459660790334, 0, +0.0878076287166289},
+			{0.2603459660790334, 0.4793080678419331, 0, +0.0878076287166289},
+			{0.0651301029022189, 0.0651301029022189, 0, +0.0266736178044215},
+			{0.8697397941955621, 0.0651301029022189, 0, +0.0266736178044215},
+			{0.0651301029022189, 0.8697397941955621, 0, +0.0266736178044215},
+			{0.0486903154253045, 0.3128654960048839, 0, +0.0385568804451212},
+			{0.3128654960048839, 0.0486903154253045, 0, +0.0385568804451212},
+			{0.0486903154253045, 0.6384441885698116, 0, +0.0385568804451212},
+			{0.6384441885698116, 0.0486903154253045, 0, +0.0385568804451212},
+			{0.3128654960048839, 0.6384441885698116, 0, +0.0385568804451212},
+			{0.6384441885698116, 0.3128654960048839, 0, +0.0385568804451212},
+		},
 		"internal_16": {
 			{3.33333333333333E-01, 3.33333333333333E-01, 0, 7.21578038388935E-02},
 			{8.14148234145540E-02, 4.59292588292723E-01, 0, 4.75458171336425E-02},
@@ -377,8 +1694,18 @@ func init() {
 		},
 	}
 
+	// auxiliary constants for Keast's rules
+	K11a, K11b := (1.0+math.Sqrt(5.0/14.0))/4.0, (1.0-math.Sqrt(5.0/14.0))/4.0
+	K15a, K15b := (7.0-SQ15)/34.0, (13.0+3.0*SQ15)/34.0
+	K15c, K15d := (7.0+SQ15)/34.0, (13.0-3.0*SQ15)/34.0
+	K15e, K15f := (5.0-SQ15)/20.0, (5.0+SQ15)/20.0
+	K15wa, K15wc := (2665.0+14.0*SQ15)/226800.0, (2665.0-14.0*SQ15)/226800.0
+
 	// set integration points for "tet" kind
-	IntPoints[KindTet] = map[string][][]float64{
+	// NOTE: (1) "internal_5" and "internal_11" have one negative weight
+	//       (2) "internal_1", "internal_4" and "internal_15" have positive weights only
+	//       (3) "internal_11" and "internal_15" are the Keast rules of degree 4 and 5, respectively
+	intPoints[KindTet] = map[string][][]float64{
 		"internal_1": {
 			{1.0 / 4.0, 1.0 / 4.0, 1.0 / 4.0, 1.0 / 6.0},
 		},
@@ -403,26 +1730,138 @@ func init() {
 			{+0.0, +0.0, +1.0, 4.0 / 3.0},
 			{+0.0, +0.0, -1.0, 4.0 / 3.0},
 		},
+		"internal_11": {
+			{1.0 / 4.0, 1.0 / 4.0, 1.0 / 4.0, -74.0 / 5625.0},
+			{1.0 / 14.0, 1.0 / 14.0, 1.0 / 14.0, 343.0 / 45000.0},
+			{11.0 / 14.0, 1.0 / 14.0, 1.0 / 14.0, 343.0 / 45000.0},
+			{1.0 / 14.0, 11.0 / 14.0, 1.0 / 14.0, 343.0 / 45000.0},
+			{1.0 / 14.0, 1.0 / 14.0, 11.0 / 14.0, 343.0 / 45000.0},
+			{K11a, K11b, K11b, 56.0 / 2250.0},
+			{K11b, K11a, K11b, 56.0 / 2250.0},
+			{K11b, K11b, K11a, 56.0 / 2250.0},
+			{K11a, K11a, K11b, 56.0 / 2250.0},
+			{K11a, K11b, K11a, 56.0 / 2250.0},
+			{K11b, K11a, K11a, 56.0 / 2250.0},
+		},
+		"internal_15": {
+			{1.0 / 4.0, 1.0 / 4.0, 1.0 / 4.0, 8.0 / 405.0},
+			{K15a, K15a, K15a, K15wa},
+			{K15b, K15a, K15a, K15wa},
+			{K15a, K15b, K15a, K15wa},
+			{K15a, K15a, K15b, K15wa},
+			{K15c, K15c, K15c, K15wc},
+			{K15d, K15c, K15c, K15wc},
+			{K15c, K15d, K15c, K15wc},
+			{K15c, K15c, K15d, K15wc},
+			{K15e, K15f, K15f, 5.0 / 567.0},
+			{K15f, K15e, K15f, 5.0 / 567.0},
+			{K15f, K15f, K15e, 5.0 / 567.0},
+			{K15e, K15e, K15f, 5.0 / 567.0},
+			{K15e, K15f, K15e, 5.0 / 567.0},
+			{K15f, K15e, K15e, 5.0 / 567.0},
+		},
+	}
+
+	// set integration points for "wed" kind
+	intPoints[KindWed] = map[string][][]float64{
+		"internal_1":  quadPointsWedge(intPoints[KindTri]["internal_1"], 1),
+		"internal_6":  quadPointsWedge(intPoints[KindTri]["internal_3"], 2),
+		"internal_18": quadPointsWedge(intPoints[KindTri]["internal_6"], 3),
+		"internal_21": quadPointsWedge(intPoints[KindTri]["internal_7"], 3),
+	}
+
+	// set integration points for "pyr" kind
+	intPoints[KindPyr] = map[string][][]float64{
+		"internal_1":  QuadPointsPyramid(1),
+		"internal_8":  QuadPointsPyramid(8),
+		"internal_27": QuadPointsPyramid(27),
+		"internal_64": QuadPointsPyramid(64),
+	}
+
+	// set polynomial degree integrated exactly by each set
+	// NOTE: the "stable" Wilson sets only integrate linear functions exactly; also, tet's
+	//       "internal_6" is not defined over the reference tetrahedron
+	intPointsDegree = map[int]map[string]int{
+		KindLin: {
+			"legendre_1":  1,
+			"legendre_2":  3,
+			"legendre_3":  5,
+			"legendre_4":  7,
+			"legendre_5":  9,
+			"legendre_6":  11,
+			"legendre_7":  13,
+			"legendre_8":  15,
+			"legendre_9":  17,
+			"legendre_10": 19,
+		},
+		KindQua: {
+			"legendre_1":       1,
+			"legendre_4":       3,
+			"legendre_9":       5,
+			"legendre_16":      7,
+			"wilson5corner_5":  3,
+			"wilson5stable_5":  1,
+			"wilson8default_8": 5,
+		},
+		KindHex: {
+			"legendre_8":      3,
+			"wilson9corner_9": 3,
+			"wilson9stable_9": 1,
+			"irons_6":         3,
+			"irons_14":        5,
+			"legendre_27":     5,
+		},
+		KindTri: {
+			"internal_1":  1,
+			"internal_3":  2,
+			"edge_3":      2,
+			"internal_4":  3,
+			"internal_6":  4,
+			"internal_7":  5,
+			"internal_12": 6,
+			"internal_13": 7,
+			"internal_16": 8,
+		},
+		KindTet: {
+			"internal_1":  1,
+			"internal_4":  2,
+			"internal_5":  3,
+			"internal_6":  -1,
+			"internal_11": 4,
+			"internal_15": 5,
+		},
+		KindWed: {
+			"internal_1":  1,
+			"internal_6":  2,
+			"internal_18": 4,
+			"internal_21": 5,
+		},
+		KindPyr: {
+			"internal_1":  1,
+			"internal_8":  3,
+			"internal_27": 5,
+			"internal_64": 7,
+		},
 	}
 
 	// set default integration points
-	DefaultIntPoints = make([][][]float64, TypeNumMax)
-	DefaultIntPoints[TypeLin2] = IntPoints[KindLin]["legendre_2"]
-	DefaultIntPoints[TypeLin3] = IntPoints[KindLin]["legendre_3"]
-	DefaultIntPoints[TypeLin4] = IntPoints[KindLin]["legendre_4"]
-	DefaultIntPoints[TypeLin5] = IntPoints[KindLin]["legendre_5"]
-	DefaultIntPoints[TypeTri3] = IntPoints[KindTri]["internal_3"]
-	DefaultIntPoints[TypeTri6] = IntPoints[KindTri]["internal_4"]
-	DefaultIntPoints[TypeTri10] = IntPoints[KindTri]["internal_12"]
-	DefaultIntPoints[TypeTri15] = IntPoints[KindTri]["internal_16"]
-	DefaultIntPoints[TypeQua4] = IntPoints[KindQua]["legendre_4"]
-	DefaultIntPoints[TypeQua8] = IntPoints[KindQua]["legendre_9"]
-	DefaultIntPoints[TypeQua9] = IntPoints[KindQua]["legendre_9"]
-	DefaultIntPoints[TypeQua12] = IntPoints[KindQua]["legendre_16"]
-	DefaultIntPoints[TypeQua16] = IntPoints[KindQua]["legendre_16"]
-	DefaultIntPoints[TypeQua17] = IntPoints[KindQua]["legendre_16"]
-	DefaultIntPoints[TypeTet4] = IntPoints[KindTet]["internal_4"]
-	DefaultIntPoints[TypeTet10] = IntPoints[KindTet]["internal_6"]
-	DefaultIntPoints[TypeHex8] = IntPoints[KindHex]["legendre_8"]
-	DefaultIntPoints[TypeHex20] = IntPoints[KindHex]["legendre_27"]
+	defaultIntPoints = make([][][]float64, TypeNumMax)
+	defaultIntPoints[TypeLin2] = intPoints[KindLin]["legendre_2"]
+	defaultIntPoints[TypeLin3] = intPoints[KindLin]["legendre_3"]
+	defaultIntPoints[TypeLin4] = intPoints[KindLin]["legendre_4"]
+	defaultIntPoints[TypeLin5] = intPoints[KindLin]["legendre_5"]
+	defaultIntPoints[TypeTri3] = intPoints[KindTri]["internal_3"]
+	defaultIntPoints[TypeTri6] = intPoints[KindTri]["internal_4"]
+	defaultIntPoints[TypeTri10] = intPoints[KindTri]["internal_12"]
+	defaultIntPoints[TypeTri15] = intPoints[KindTri]["internal_16"]
+	defaultIntPoints[TypeQua4] = intPoints[KindQua]["legendre_4"]
+	defaultIntPoints[TypeQua8] = intPoints[KindQua]["legendre_9"]
+	defaultIntPoints[TypeQua9] = intPoints[KindQua]["legendre_9"]
+	defaultIntPoints[TypeQua12] = intPoints[KindQua]["legendre_16"]
+	defaultIntPoints[TypeQua16] = intPoints[KindQua]["legendre_16"]
+	defaultIntPoints[TypeQua17] = intPoints[KindQua]["legendre_16"]
+	defaultIntPoints[TypeTet4] = intPoints[KindTet]["internal_4"]
+	defaultIntPoints[TypeTet10] = intPoints[KindTet]["internal_6"]
+	defaultIntPoints[TypeHex8] = intPoints[KindHex]["legendre_8"]
+	defaultIntPoints[TypeHex20] = intPoints[KindHex]["legendre_27"]
 }
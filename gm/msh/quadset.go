@@ -0,0 +1,1210 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package msh
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/gob"
+	"encoding/json"
+	goio "io"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/fun/dbf"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/plt"
+	"github.com/cpmech/gosl/utl"
+)
+
+// IntPointsSet holds a named set of integration points; e.g. to be saved to or loaded from files
+type IntPointsSet struct {
+	Kind int         `json:"kind"` // cell kind; e.g. KindQua
+	Name string      `json:"name"` // name of set; e.g. "legendre_4"
+	Ndim int         `json:"ndim"` // space dimension
+	Npts int         `json:"npts"` // number of points = len(P)
+	P    [][]float64 `json:"p"`    // integration points [npts][4] where 4 means r,s,t,w
+
+	// metadata [optional]
+	Source         string `json:"source,omitempty"`         // reference to the source of the set; e.g. a publication
+	RecommendedFor string `json:"recommendedFor,omitempty"` // note on the intended use of the set
+
+	// derived
+	weights   []float64  // weights returned by WeightsView; built lazily [npts]
+	weightsOf *[]float64 // address of the first point when weights were built; to detect a new P
+}
+
+// NewIntPointsSet returns a new set of integration points taken from the database of integration points (see GetIntPoints)
+func NewIntPointsSet(cellKind int, setName string) (o *IntPointsSet) {
+	o = new(IntPointsSet)
+	o.Kind = cellKind
+	o.Name = setName
+	o.Ndim = kindNdim(cellKind)
+	o.P = IntPointsFindSet(cellKind, setName)
+	o.Npts = len(o.P)
+	o.Source, o.RecommendedFor = intPointsMeta(cellKind, setName)
+	return
+}
+
+// NewIntPointsSetDefault returns the recommended default set of integration points for a cell
+// kind; i.e. the set for full integration of linear elements:
+//   lin -- "legendre_2"
+//   tri -- "internal_3"
+//   qua -- "legendre_4"
+//   tet -- "internal_4"
+//   hex -- "legendre_8"
+//   wed -- "internal_6"
+//   pyr -- "internal_8"
+//   NOTE: the sets for lin, tri, qua, tet and hex are the same as in GetDefaultIntPoints for TypeLin2,
+//         TypeTri3, TypeQua4, TypeTet4 and TypeHex8
+func NewIntPointsSetDefault(cellKind int) (o *IntPointsSet) {
+	names := []string{
+		KindLin: "legendre_2",
+		KindTri: "internal_3",
+		KindQua: "legendre_4",
+		KindTet: "internal_4",
+		KindHex: "legendre_8",
+		KindWed: "internal_6",
+		KindPyr: "internal_8",
+	}
+	if cellKind < 0 || cellKind >= len(names) {
+		chk.Panic("cellKind = %d is invalid\n", cellKind)
+	}
+	return NewIntPointsSet(cellKind, names[cellKind])
+}
+
+// NewIntPointsSetReduced returns the set of integration points for reduced integration of linear
+// elements; i.e. a single point at the centroid of the reference cell. These sets under-integrate
+// the stiffness of linear elements on purpose; e.g. to avoid volumetric locking. Nonetheless,
+// spurious zero-energy (hourglass) modes may appear if used for all terms
+func NewIntPointsSetReduced(cellKind int) (o *IntPointsSet) {
+	names := []string{
+		KindLin: "legendre_1",
+		KindTri: "internal_1",
+		KindQua: "legendre_1",
+		KindTet: "internal_1",
+		KindHex: "legendre_1",
+		KindWed: "internal_1",
+		KindPyr: "internal_1",
+	}
+	if cellKind < 0 || cellKind >= len(names) {
+		chk.Panic("cellKind = %d is invalid\n", cellKind)
+	}
+	return NewIntPointsSet(cellKind, names[cellKind])
+}
+
+// NewIntPointsSetLattice returns a set with a regular lattice of sample points within the reference
+// cell; e.g. for visualisation. See QuadPointsLattice
+func NewIntPointsSetLattice(cellKind, nPerDim int) (o *IntPointsSet) {
+	P := QuadPointsLattice(cellKind, nPerDim)
+	return &IntPointsSet{Kind: cellKind, Name: io.Sf("lattice_%d", len(P)), Ndim: kindNdim(cellKind), Npts: len(P), P: P}
+}
+
+// NewIntPointsSetMonteCarlo returns a set with pseudo-random points uniformly distributed within
+// the reference cell. See QuadPointsMonteCarlo
+func NewIntPointsSetMonteCarlo(cellKind, npts int, seed int64) (o *IntPointsSet) {
+	P := QuadPointsMonteCarlo(cellKind, npts, seed)
+	return &IntPointsSet{Kind: cellKind, Name: io.Sf("montecarlo_%d", npts), Ndim: kindNdim(cellKind), Npts: npts, P: P}
+}
+
+// NewIntPointsSetQuasiMonteCarlo returns a set with quasi-random points within the reference cell
+//   sequence -- "sobol" or "halton". See QuadPointsQuasiMonteCarlo
+func NewIntPointsSetQuasiMonteCarlo(cellKind, npts int, sequence string) (o *IntPointsSet) {
+	P := QuadPointsQuasiMonteCarlo(cellKind, npts, sequence)
+	return &IntPointsSet{Kind: cellKind, Name: io.Sf("%s_%d", sequence, npts), Ndim: kindNdim(cellKind), Npts: npts, P: P}
+}
+
+// NewIntPointsSetClenshawCurtis returns the set with the points of the nested Clenshaw-Curtis rule of
+// a given level for lin cells; e.g. "clenshawcurtis_5" for level 3. See QuadPointsClenshawCurtis
+func NewIntPointsSetClenshawCurtis(level int) (o *IntPointsSet) {
+	P := QuadPointsClenshawCurtis(level)
+	return &IntPointsSet{Kind: KindLin, Name: io.Sf("clenshawcurtis_%d", len(P)), Ndim: 1, Npts: len(P), P: P}
+}
+
+// NewIntPointsSetMixedTensor returns a set for lin, qua or hex cells with the tensor product of 1D
+// rules of possibly different families along each direction; e.g. "legendre-legendre-lobatto_18"
+// for rules = {"legendre", "legendre", "lobatto"} and nPerDim = {3, 3, 2}. See QuadPointsMixedTensor
+func NewIntPointsSetMixedTensor(rules []string, nPerDim []int) (o *IntPointsSet) {
+	P := QuadPointsMixedTensor(rules, nPerDim)
+	ndim := len(nPerDim)
+	cellKind := []int{KindLin, KindQua, KindHex}[ndim-1]
+	return &IntPointsSet{Kind: cellKind, Name: io.Sf("%s_%d", strings.Join(rules, "-"), len(P)), Ndim: ndim, Npts: len(P), P: P}
+}
+
+// NewIntPointsSetsSelective returns the sets of integration points for selective reduced
+// integration of linear elements; e.g. the full set for the deviatoric terms and the reduced set
+// for the volumetric terms. See NewIntPointsSetDefault and NewIntPointsSetReduced
+func NewIntPointsSetsSelective(cellKind int) (full, reduced *IntPointsSet) {
+	return NewIntPointsSetDefault(cellKind), NewIntPointsSetReduced(cellKind)
+}
+
+// NewIntPointsSetByDegree returns the cheapest set of integration points which integrates exactly
+// all polynomials of a given degree. See IntPointsSelectSet
+func NewIntPointsSetByDegree(cellKind int, degree int) (o *IntPointsSet, err error) {
+	setName, err := IntPointsSelectSet(cellKind, degree)
+	if err != nil {
+		return
+	}
+	return NewIntPointsSet(cellKind, setName), nil
+}
+
+// NewIntPointsSetAutoTuned returns the Gauss-Legendre set for lin, qua or hex cells with the
+// smallest number of points along each direction (n1d) such that the integrals of the given
+// function computed with n1d-1 and n1d points agree within tolerance
+//   Input:
+//     f      -- integrand function; e.g. a sample of the actual integrand
+//     ndim   -- space dimension: 1 (lin), 2 (qua) or 3 (hex)
+//     tol    -- absolute tolerance
+//     maxN1d -- maximum number of points along each direction
+//   Output:
+//     o   -- the selected set; e.g. "legendre_9" for qua with n1d=3
+//     res -- the integral computed with the selected set
+//     err -- an error is returned if tol could not be met with up to maxN1d points along each
+//            direction; o and res correspond to maxN1d in this case
+//   NOTE: this is suitable for smooth integrands. See also QuadPointsIntegratePAdaptive
+func NewIntPointsSetAutoTuned(f fun.Sv, ndim int, tol float64, maxN1d int) (o *IntPointsSet, res float64, err error) {
+	if ndim < 1 || ndim > 3 {
+		return nil, 0, chk.Err("ndim=%d is invalid. ndim must be 1, 2, or 3\n", ndim)
+	}
+	cellKind := []int{KindLin, KindQua, KindHex}[ndim-1]
+	for n1d := 1; n1d <= maxN1d; n1d++ {
+		npts := int(math.Pow(float64(n1d), float64(ndim)) + 0.5)
+		P := QuadPointsGaussLegendre(ndim, npts)
+		val := QuadPointsIntegrate(P, ndim, f)
+		o = &IntPointsSet{Kind: cellKind, Name: io.Sf("legendre_%d", npts), Ndim: ndim, Npts: npts, P: P}
+		if n1d > 1 && math.Abs(val-res) <= tol {
+			return o, val, nil
+		}
+		res = val
+	}
+	return o, res, chk.Err("tolerance %g could not be met with up to %d points along each direction\n", tol, maxN1d)
+}
+
+// NewIntPointsSetsKronrod returns the Gauss-Legendre set with npts points and its Kronrod
+// extension with 2⋅npts+1 points for lin cells. See QuadPointsGaussKronrod
+func NewIntPointsSetsKronrod(npts int) (gauss, kronrod *IntPointsSet) {
+	pg, pk := QuadPointsGaussKronrod(npts)
+	gauss = &IntPointsSet{Kind: KindLin, Name: io.Sf("legendre_%d", npts), Ndim: 1, Npts: len(pg), P: pg}
+	kronrod = &IntPointsSet{Kind: KindLin, Name: io.Sf("kronrod_%d", len(pk)), Ndim: 1, Npts: len(pk), P: pk}
+	return
+}
+
+// NewIntPointsSetForShape returns a new set of integration points with the space dimension
+// inferred from the shape
+//   shape -- "lin", "tri", "qua", "tet", "hex", "wed" or "pyr"; type keys such as "qua8" are also accepted
+//   rule  -- "legendre", "lobatto", "radauleft", "radauright", "newtoncotes" or "newtoncotesopen"
+//            for lin, qua and hex; "wilson5" or "wilson8" for qua; "wilson9" for hex; or ""
+//            to take the first set in the database of integration points (see GetIntPoints) with npts points
+//   npts  -- total number of points; e.g. 9 for 3 points along each direction of a qua
+//            the number of points along each direction must be at least 1 for legendre and radau,
+//            at least 2 for lobatto, in [2,7] for newtoncotes and in [1,5] for newtoncotesopen
+//   prms  -- parameters of Wilson's rules [may be nil]: "w0" and "stable" for wilson5 and
+//            wilson9; "wb" for wilson8. See QuadPointsWilson5, QuadPointsWilson8 and QuadPointsWilson9
+//   NOTE: an error is returned if the rule is incompatible with the shape or npts
+func NewIntPointsSetForShape(shape, rule string, npts int, prms dbf.Params) (o *IntPointsSet, err error) {
+
+	// cell kind
+	cellKind := -1
+	if len(shape) >= 3 {
+		for kind, name := range kindShapes {
+			if shape[:3] == name {
+				cellKind = kind
+			}
+		}
+	}
+	if cellKind < 0 {
+		return nil, chk.Err("shape %q is invalid\n", shape)
+	}
+	ndim := kindNdim(cellKind)
+
+	// points
+	var P [][]float64
+	setName := io.Sf("%s_%d", rule, npts)
+	switch rule {
+	case "":
+		setNames := IntPointsFindByNpts(cellKind, npts)
+		if len(setNames) == 0 {
+			return nil, chk.Err("there is no set with npts=%d for shape %q\n", npts, shape)
+		}
+		return NewIntPointsSet(cellKind, setNames[0]), nil
+
+	case "legendre", "lobatto", "radauleft", "radauright", "newtoncotes", "newtoncotesopen":
+		if cellKind != KindLin && cellKind != KindQua && cellKind != KindHex {
+			return nil, chk.Err("rule %q requires a lin, qua or hex shape. %q is invalid\n", rule, shape)
+		}
+		n1d := int(math.Floor(math.Pow(float64(npts), 1.0/float64(ndim)) + 0.5))
+		nPerDim := utl.IntVals(ndim, n1d)
+		if n1d < 1 || int(math.Pow(float64(n1d), float64(ndim))+0.5) != npts {
+			return nil, chk.Err("npts=%d cannot be represented by rule %q with ndim=%d\n", npts, rule, ndim)
+		}
+		n1dMin, n1dMax := 1, math.MaxInt32
+		switch rule {
+		case "lobatto":
+			n1dMin = 2
+		case "newtoncotes":
+			n1dMin, n1dMax = 2, 7
+		case "newtoncotesopen":
+			n1dMax = 5
+		}
+		if n1d < n1dMin || n1d > n1dMax {
+			if n1dMax == math.MaxInt32 {
+				return nil, chk.Err("rule %q requires at least %d points along each direction. n1d=%d is invalid\n", rule, n1dMin, n1d)
+			}
+			return nil, chk.Err("rule %q requires %d to %d points along each direction. n1d=%d is invalid\n", rule, n1dMin, n1dMax, n1d)
+		}
+		P = QuadPointsGaussTensor(rule, nPerDim)
+
+	case "wilson5", "wilson8", "wilson9":
+		nw, kw, kname, w0max := 5, KindQua, "qua", 4.0
+		if rule == "wilson8" {
+			nw = 8
+		} else if rule == "wilson9" {
+			nw, kw, kname, w0max = 9, KindHex, "hex", 8.0
+		}
+		if cellKind != kw {
+			return nil, chk.Err("rule %q requires a %s shape. %q is invalid\n", rule, kname, shape)
+		}
+		if npts != nw {
+			return nil, chk.Err("rule %q has %d points. npts=%d is invalid\n", rule, nw, npts)
+		}
+		w0, wb := prms.GetValueOrDefault("w0", 0), prms.GetValueOrDefault("wb", 0)
+		if rule == "wilson8" && wb >= 1 {
+			return nil, chk.Err("wb must be in (0,1) for rule %q. wb = %g is invalid\n", rule, wb)
+		}
+		if rule != "wilson8" && !prms.GetBoolOrDefault("stable", false) && w0 >= w0max {
+			return nil, chk.Err("w0 must be in (0,%g) for rule %q. w0 = %g is invalid\n", w0max, rule, w0)
+		}
+		switch rule {
+		case "wilson5":
+			P = QuadPointsWilson5(w0, prms.GetBoolOrDefault("stable", false))
+		case "wilson8":
+			P = QuadPointsWilson8(wb)
+		default:
+			P = QuadPointsWilson9(w0, prms.GetBoolOrDefault("stable", false))
+		}
+
+	default:
+		return nil, chk.Err("rule %q is invalid\n", rule)
+	}
+	o = &IntPointsSet{Kind: cellKind, Name: setName, Ndim: ndim, Npts: len(P), P: P}
+	return
+}
+
+// NewIntPointsSetFace returns a set of integration points over a face (or edge) of a reference
+// cell and the mapping from the local coordinates of the face to the coordinates of the cell
+//   cellKind  -- kind of the parent cell: KindTri, KindQua, KindTet or KindHex
+//   faceIndex -- index of face (edge in 2D) as in FaceLocalVerts (EdgeLocalVerts in 2D)
+//   setName   -- name of set for the kind of face; e.g. "legendre_4" for the qua faces of hex
+//   NOTE: (1) the faces of tri and qua are lin; the faces of tet are tri; the faces of hex are qua
+//         (2) the points of the returned set are given in the local coordinates of the face
+//         (3) the weights are multiplied by the ratio between the measure of the face in the
+//             parent reference cell and the measure of the reference face; thus, the sum of
+//             weights equals the length or area of the face of the reference cell
+func NewIntPointsSetFace(cellKind, faceIndex int, setName string) (face *IntPointsSet, mapToParent func(ξ []float64) (x []float64)) {
+
+	// topology
+	var ctype, faceKind int
+	var faces [][]int
+	switch cellKind {
+	case KindTri:
+		ctype, faceKind, faces = TypeTri3, KindLin, EdgeLocalVerts[TypeTri3]
+	case KindQua:
+		ctype, faceKind, faces = TypeQua4, KindLin, EdgeLocalVerts[TypeQua4]
+	case KindTet:
+		ctype, faceKind, faces = TypeTet4, KindTri, FaceLocalVerts[TypeTet4]
+	case KindHex:
+		ctype, faceKind, faces = TypeHex8, KindQua, FaceLocalVerts[TypeHex8]
+	default:
+		chk.Panic("faces of cellKind = %d are not available\n", cellKind)
+	}
+	if faceIndex < 0 || faceIndex >= len(faces) {
+		chk.Panic("faceIndex = %d is invalid for cellKind = %d\n", faceIndex, cellKind)
+	}
+	ndim := kindNdim(cellKind)
+	X := make([][]float64, len(faces[faceIndex])) // coordinates of vertices of face
+	for k, m := range faces[faceIndex] {
+		X[k] = make([]float64, ndim)
+		for j := 0; j < ndim; j++ {
+			X[k][j] = NatCoords[ctype][j][m]
+		}
+	}
+
+	// mapping: linear (lin and tri) or bilinear (qua) interpolation of vertices
+	mapToParent = func(ξ []float64) (x []float64) {
+		var S []float64
+		switch faceKind {
+		case KindLin:
+			S = []float64{(1 - ξ[0]) / 2, (1 + ξ[0]) / 2}
+		case KindTri:
+			S = []float64{1 - ξ[0] - ξ[1], ξ[0], ξ[1]}
+		case KindQua:
+			S = []float64{(1 - ξ[0]) * (1 - ξ[1]) / 4, (1 + ξ[0]) * (1 - ξ[1]) / 4, (1 + ξ[0]) * (1 + ξ[1]) / 4, (1 - ξ[0]) * (1 + ξ[1]) / 4}
+		}
+		x = make([]float64, ndim)
+		for k, sk := range S {
+			for j := 0; j < ndim; j++ {
+				x[j] += sk * X[k][j]
+			}
+		}
+		return
+	}
+
+	// ratio of measures: the faces of the reference cells are straight or flat parallelograms
+	var ratio float64
+	a := make([]float64, 3)
+	b := make([]float64, 3)
+	for j := 0; j < ndim; j++ {
+		a[j] = X[1][j] - X[0][j]
+		b[j] = X[len(X)-1][j] - X[0][j]
+	}
+	switch faceKind {
+	case KindLin:
+		ratio = math.Sqrt(a[0]*a[0]+a[1]*a[1]) / 2
+	default:
+		c := []float64{a[1]*b[2] - a[2]*b[1], a[2]*b[0] - a[0]*b[2], a[0]*b[1] - a[1]*b[0]}
+		area := math.Sqrt(c[0]*c[0] + c[1]*c[1] + c[2]*c[2])
+		if faceKind == KindTri {
+			area /= 2
+		}
+		measure, _ := ReferenceMeasure(faceKind)
+		ratio = area / measure
+	}
+
+	// set of points
+	face = NewIntPointsSet(faceKind, setName).ScaleWeights(ratio)
+	return
+}
+
+// NewIntPointsSetFromCoords returns a new set of integration points with given coordinates and weights
+//   X -- coordinates [npts][ndim] where ndim must correspond to cellKind
+//   W -- weights [npts]
+func NewIntPointsSetFromCoords(cellKind int, setName string, X [][]float64, W []float64) (o *IntPointsSet, err error) {
+	if cellKind < 0 || cellKind >= KindNumMax {
+		return nil, chk.Err("cellKind = %d is invalid\n", cellKind)
+	}
+	ndim := kindNdim(cellKind)
+	for i, x := range X {
+		if len(x) != ndim {
+			return nil, chk.Err("point %d has %d coordinates but cellKind = %d requires %d\n", i, len(x), cellKind, ndim)
+		}
+	}
+	P, err := QuadPointsFromCoords(X, W)
+	if err != nil {
+		return
+	}
+	o = &IntPointsSet{Kind: cellKind, Name: setName, Ndim: ndim, Npts: len(P), P: P}
+	return
+}
+
+// NewIntPointsSetFromFlat returns a new set of integration points with given coordinates and weights
+// stored in contiguous slices (see Flatten)
+//   coords  -- coordinates in row-major order [npts*ndim] where ndim must correspond to cellKind
+//   weights -- weights [npts]
+func NewIntPointsSetFromFlat(cellKind int, setName string, coords, weights []float64) (o *IntPointsSet, err error) {
+	if cellKind < 0 || cellKind >= KindNumMax {
+		return nil, chk.Err("cellKind = %d is invalid\n", cellKind)
+	}
+	ndim := kindNdim(cellKind)
+	if len(coords) != ndim*len(weights) {
+		return nil, chk.Err("number of coordinates %d is incompatible with %d weights and ndim=%d\n", len(coords), len(weights), ndim)
+	}
+	X := make([][]float64, len(weights))
+	for i := range X {
+		X[i] = coords[i*ndim : (i+1)*ndim]
+	}
+	return NewIntPointsSetFromCoords(cellKind, setName, X, weights)
+}
+
+// ReadIntPointsSetCSV reads a set of integration points written by WriteCSV
+//   r -- reader with the header x0,...,weight followed by one row per point
+//   NOTE: the number of coordinate columns must correspond to cellKind
+func ReadIntPointsSetCSV(r goio.Reader, cellKind int, setName string) (o *IntPointsSet, err error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return
+	}
+	if len(rows) < 1 {
+		return nil, chk.Err("csv data must have at least the header\n")
+	}
+	X := make([][]float64, len(rows)-1)
+	W := make([]float64, len(rows)-1)
+	for i, row := range rows[1:] {
+		if len(row) != len(rows[0]) {
+			return nil, chk.Err("row %d has %d columns but the header has %d\n", i+1, len(row), len(rows[0]))
+		}
+		X[i] = make([]float64, len(row)-1)
+		for j, str := range row {
+			v, e := strconv.ParseFloat(str, 64)
+			if e != nil {
+				return nil, chk.Err("cannot parse value in row %d and column %d:\n%v\n", i+1, j, e)
+			}
+			if j < len(row)-1 {
+				X[i][j] = v
+			} else {
+				W[i] = v
+			}
+		}
+	}
+	return NewIntPointsSetFromCoords(cellKind, setName, X, W)
+}
+
+// IntPointsRegister adds a set of integration points to the database of integration points (see GetIntPoints), making it
+// available to IntPointsFindSet and NewIntPointsSet
+//   overwrite -- replace an existing set with the same cell kind and name; otherwise an
+//                error is returned if the set already exists
+//   NOTE: (1) the points are copied; thus set can be modified afterwards
+//         (2) the database is not protected against concurrent access; therefore, sets
+//             should be registered before using the database; e.g. in an init function
+func IntPointsRegister(set *IntPointsSet, overwrite bool) (err error) {
+	if set.Kind < 0 || set.Kind >= KindNumMax {
+		return chk.Err("cellKind = %d is invalid\n", set.Kind)
+	}
+	if set.Name == "" {
+		return chk.Err("name of set of integration points must not be empty\n")
+	}
+	err = set.check()
+	if err != nil {
+		return
+	}
+	if _, ok := GetIntPoints()[set.Kind][set.Name]; ok && !overwrite {
+		return chk.Err("integration points set named = %q for cellKind = %d exists already\n", set.Name, set.Kind)
+	}
+	if _, ok := GetIntPoints()[set.Kind]; !ok {
+		GetIntPoints()[set.Kind] = make(map[string][][]float64)
+	}
+	GetIntPoints()[set.Kind][set.Name] = utl.Clone(set.P)
+	delete(getIntPointsDegree()[set.Kind], set.Name)
+	return
+}
+
+// Clone returns a deep copy of this set; e.g. to be modified without side effects
+//   NOTE: NewIntPointsSet shares the points with the database of integration points (see GetIntPoints); thus Clone must
+//         be called before modifying the points
+func (o *IntPointsSet) Clone() (p *IntPointsSet) {
+	p = new(IntPointsSet)
+	*p = *o
+	p.P = utl.Clone(o.P)
+	return
+}
+
+// Coords returns copies of the coordinates [npts][ndim] and weights [npts] of this set
+func (o *IntPointsSet) Coords() (X [][]float64, W []float64) {
+	return QuadPointsToCoords(o.P, o.Ndim)
+}
+
+// Weights returns a copy of the weights of the points of this set [npts]
+func (o *IntPointsSet) Weights() (W []float64) {
+	return QuadPointsWeights(o.P)
+}
+
+// ToMatrix returns a copy of the coordinates of the points of this set as a matrix [npts][ndim];
+// i.e. the rows correspond to points and the columns to coordinates
+func (o *IntPointsSet) ToMatrix() (X *la.Matrix) {
+	X = la.NewMatrix(len(o.P), o.Ndim)
+	for i, p := range o.P {
+		for j := 0; j < o.Ndim; j++ {
+			X.Set(i, j, p[j])
+		}
+	}
+	return
+}
+
+// WeightsVector returns a copy of the weights of the points of this set as a vector [npts]
+func (o *IntPointsSet) WeightsVector() (w la.Vector) {
+	return la.NewVectorSlice(QuadPointsWeights(o.P))
+}
+
+// WeightsView returns the weights of the points of this set [npts] without allocating a new slice
+// after the first call; e.g. for hot loops. The slice is built lazily and stored in this set
+//   NOTE: (1) the returned slice must not be modified
+//         (2) the weights must not be modified after the first call because the stored slice
+//             would not be updated. Weights should be used instead in this case
+//         (3) the first call is not safe for concurrent use; call WeightsView once before
+//             sharing the set between goroutines
+func (o *IntPointsSet) WeightsView() (W []float64) {
+	if len(o.P) == 0 {
+		return nil
+	}
+	if o.weightsOf != &o.P[0] || len(o.weights) != len(o.P) {
+		o.weights = QuadPointsWeights(o.P)
+		o.weightsOf = &o.P[0]
+	}
+	return o.weights
+}
+
+// Barycentric returns the barycentric coordinates of the points of this set for triangles or
+// tetrahedra. See QuadPointsToBarycentric
+func (o *IntPointsSet) Barycentric() (L [][]float64) {
+	return QuadPointsToBarycentric(o.P, o.Kind)
+}
+
+// IntegrateValues computes the weighted sum of values of a function previously computed at the
+// points of this set (e.g. at the coordinates given by Coords). See QuadPointsIntegrateValues
+func (o *IntPointsSet) IntegrateValues(values []float64) (res float64) {
+	return QuadPointsIntegrateValues(o.P, values)
+}
+
+// IntegrateFunc integrates f over the reference cell using this set. See QuadPointsIntegrate
+//   NOTE: f is called with the ndim coordinates of each point
+func (o *IntPointsSet) IntegrateFunc(f fun.Sv) (res float64) {
+	return QuadPointsIntegrate(o.P, o.Ndim, f)
+}
+
+// IntegrateStrict integrates f over the reference cell using this set as IntegrateFunc does, but
+// returns an error instead if this set cannot integrate exactly polynomials of the degree of the
+// integrand. See WarnIfInsufficient
+//   integrandDegree -- polynomial degree of f (or of a polynomial approximating f)
+func (o *IntPointsSet) IntegrateStrict(f fun.Sv, integrandDegree int) (res float64, err error) {
+	err = o.WarnIfInsufficient(integrandDegree)
+	if err != nil {
+		return
+	}
+	return o.IntegrateFunc(f), nil
+}
+
+// IntegrateCurved integrates f over a (curved) physical cell given by a mapping from the reference
+// cell of this set, computing the Jacobian by central differences with step h. See QuadPointsIntegrateCurved
+func (o *IntPointsSet) IntegrateCurved(f func(phys []float64) float64, mapFn func(ref []float64) []float64, h float64) (res float64) {
+	return QuadPointsIntegrateCurved(o.P, o.Ndim, f, mapFn, h)
+}
+
+// IntegrateDbf integrates a database function y = F(t, x) at time t over the reference cell using
+// this set; e.g. a function allocated by dbf.New("xpoly2", prms)
+//   NOTE: F is called with the 3 coordinates (r,s,t) of each point, where the unused ones are zero;
+//         thus, functions of x[0], x[1] and x[2] such as "xpoly1" and "xpoly2" work with any ndim
+func (o *IntPointsSet) IntegrateDbf(f dbf.T, t float64) (res float64) {
+	for _, p := range o.P {
+		res += f.F(t, p[:3]) * p[3]
+	}
+	return
+}
+
+// Iterate calls fn for each point of this set in order and stops if fn returns false
+//   i -- index of point
+//   x -- new copy of the coordinates of point [ndim]; i.e. x may be kept by fn and changes to x
+//        do not affect this set
+//   w -- weight of point
+func (o *IntPointsSet) Iterate(fn func(i int, x []float64, w float64) bool) {
+	for i, p := range o.P {
+		x := make([]float64, o.Ndim)
+		copy(x, p[:o.Ndim])
+		if !fn(i, x, p[3]) {
+			return
+		}
+	}
+}
+
+// Bounds returns the limits of the bounding box of the points of this set. See QuadPointsBounds
+func (o *IntPointsSet) Bounds() (xmin, xmax []float64) {
+	return QuadPointsBounds(o.P, o.Ndim)
+}
+
+// Centroid returns the weighted centroid of the points of this set. See QuadPointsCentroid
+func (o *IntPointsSet) Centroid() (xc []float64) {
+	return QuadPointsCentroid(o.P, o.Ndim)
+}
+
+// Flatten returns copies of the coordinates and weights of this set in contiguous slices; e.g. to
+// be passed to C or Fortran routines
+//   coords  -- coordinates in row-major order [npts*ndim]; i.e. x0,y0,x1,y1,... in 2D
+//   weights -- weights [npts]
+func (o *IntPointsSet) Flatten() (coords, weights []float64) {
+	coords = make([]float64, o.Npts*o.Ndim)
+	weights = make([]float64, o.Npts)
+	for i, p := range o.P {
+		copy(coords[i*o.Ndim:(i+1)*o.Ndim], p[:o.Ndim])
+		weights[i] = p[3]
+	}
+	return
+}
+
+// Transform returns a new set with the points mapped to a physical cell and the weights multiplied
+// by the determinant of the Jacobian of the mapping (see QuadPointsTransform)
+//   NOTE: the name of the new set is suffixed with "-mapped" to indicate that the points are
+//         no longer within the reference cell
+func (o *IntPointsSet) Transform(mapFn func(ref []float64) (phys []float64, detJ float64)) (p *IntPointsSet) {
+	p = new(IntPointsSet)
+	*p = *o
+	p.Name = o.Name + "-mapped"
+	p.P = QuadPointsTransform(o.P, o.Ndim, mapFn)
+	return
+}
+
+// ToUnitInterval returns a new set with the points mapped from [-1,1]^ndim to [0,1]^ndim and the
+// weights scaled accordingly (see QuadPointsToUnitInterval). The name of the new set is suffixed
+// with "-unit"
+//   NOTE: only sets for lin, qua and hex cells can be mapped
+func (o *IntPointsSet) ToUnitInterval() (p *IntPointsSet) {
+	if o.Kind != KindLin && o.Kind != KindQua && o.Kind != KindHex {
+		chk.Panic("only sets for lin, qua and hex cells can be mapped to the unit interval. cellKind = %d is invalid\n", o.Kind)
+	}
+	p = new(IntPointsSet)
+	*p = *o
+	p.Name = o.Name + "-unit"
+	p.P = QuadPointsToUnitInterval(o.P, o.Ndim)
+	return
+}
+
+// Refine returns the next set of a nested family of sets for lin cells and the indices of the points
+// of the refined set which are not in this set; thus, the values of the integrand already computed at
+// the points of this set can be reused. The nested families are:
+//   "legendre_n"       → "kronrod_2n+1"             (Gauss-Kronrod; see NewIntPointsSetsKronrod)
+//   "clenshawcurtis_m" → "clenshawcurtis_2(m-1)+1"  (see NewIntPointsSetClenshawCurtis)
+//   NOTE: the points are compared by their positions with tolerance 1e-14
+func (o *IntPointsSet) Refine() (refined *IntPointsSet, newPointIndices []int) {
+	var n int
+	var err error
+	switch {
+	case o.Kind == KindLin && strings.HasPrefix(o.Name, "legendre_"):
+		n, err = strconv.Atoi(strings.TrimPrefix(o.Name, "legendre_"))
+		if err == nil && n > 0 {
+			_, refined = NewIntPointsSetsKronrod(n)
+		}
+	case o.Kind == KindLin && strings.HasPrefix(o.Name, "clenshawcurtis_"):
+		n, err = strconv.Atoi(strings.TrimPrefix(o.Name, "clenshawcurtis_"))
+		for level := 1; err == nil && level < 20; level++ {
+			if quadPointsClenshawCurtisM(level) == n {
+				refined = NewIntPointsSetClenshawCurtis(level + 1)
+				break
+			}
+		}
+	}
+	if refined == nil {
+		chk.Panic("set named %q with cellKind = %d cannot be refined\n", o.Name, o.Kind)
+	}
+	for i, q := range refined.P {
+		found := false
+		for _, p := range o.P {
+			if math.Abs(p[0]-q[0]) <= 1e-14 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			newPointIndices = append(newPointIndices, i)
+		}
+	}
+	if len(refined.P)-len(newPointIndices) != len(o.P) {
+		chk.Panic("set named %q is not nested in the refined set %q\n", o.Name, refined.Name)
+	}
+	return
+}
+
+// AppendTo appends (copies of) the points of this set to dst and updates dst.Npts; e.g. to build
+// composite sets incrementally
+//   NOTE: (1) the cell kinds of both sets must be equal; e.g. tri and qua sets cannot be mixed
+//         (2) the points already in dst are not modified; thus dst may share its points
+//             with the database of integration points (e.g. if created by NewIntPointsSet)
+func (o *IntPointsSet) AppendTo(dst *IntPointsSet) (err error) {
+	if o.Kind != dst.Kind {
+		return chk.Err("cannot append set with cellKind = %d to set with cellKind = %d\n", o.Kind, dst.Kind)
+	}
+	n := len(dst.P)
+	dst.P = append(dst.P[:n:n], utl.Clone(o.P)...)
+	dst.Npts = len(dst.P)
+	return
+}
+
+// ScaleWeights returns a new set with all weights multiplied by factor
+func (o *IntPointsSet) ScaleWeights(factor float64) (p *IntPointsSet) {
+	p = new(IntPointsSet)
+	*p = *o
+	p.P = QuadPointsScaleWeights(o.P, factor)
+	return
+}
+
+// SortByWeight returns a new set with the points sorted by weight. See QuadPointsSortByWeight
+func (o *IntPointsSet) SortByWeight(descending bool) (p *IntPointsSet) {
+	p = new(IntPointsSet)
+	*p = *o
+	p.P = QuadPointsSortByWeight(o.P, descending)
+	return
+}
+
+// CollapseDuplicates returns a new set where points with coincident coordinates are merged.
+// See QuadPointsCollapseDuplicates
+func (o *IntPointsSet) CollapseDuplicates(tol float64) (p *IntPointsSet) {
+	p = new(IntPointsSet)
+	*p = *o
+	p.P = QuadPointsCollapseDuplicates(o.P, tol)
+	p.Npts = len(p.P)
+	return
+}
+
+// ForSimplex returns a new set with the points mapped to a physical triangle or tetrahedron and
+// the weights multiplied by the constant determinant of the Jacobian (see QuadPointsForSimplex)
+//   vertices -- coordinates of vertices [ndim+1][ndim]
+//   NOTE: the name of the new set is suffixed with "-mapped"
+func (o *IntPointsSet) ForSimplex(vertices [][]float64) (p *IntPointsSet) {
+	if o.Kind != KindTri && o.Kind != KindTet {
+		chk.Panic("ForSimplex requires a set for triangles or tetrahedra. cellKind = %d is invalid\n", o.Kind)
+	}
+	if len(vertices) != o.Ndim+1 {
+		chk.Panic("number of vertices must be %d. %d is invalid\n", o.Ndim+1, len(vertices))
+	}
+	p = new(IntPointsSet)
+	*p = *o
+	p.Name = o.Name + "-mapped"
+	p.P = QuadPointsForSimplex(o.P, vertices)
+	return
+}
+
+// AllInReferenceCell returns whether all points of this set are inside the reference cell or not
+//   tol -- tolerance to consider points on the boundary
+func (o *IntPointsSet) AllInReferenceCell(tol float64) bool {
+	for _, p := range o.P {
+		if !InReferenceCell(o.Kind, p, tol) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsOpen returns whether all points of this set are in the interior of the reference cell or not;
+// i.e. whether no point is on the boundary. See also QuadPointsRuleIsOpen
+func (o *IntPointsSet) IsOpen() bool {
+	for _, p := range o.P {
+		if !InReferenceCell(o.Kind, p, -1e-15) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate checks the consistency of this set and whether the points and weights are valid for
+// its cell kind (see QuadPointsValidate)
+//   tol -- tolerance for the position of points and for the sum of weights
+func (o *IntPointsSet) Validate(tol float64) (err error) {
+	err = o.check()
+	if err != nil {
+		return
+	}
+	return QuadPointsValidate(o.P, o.Kind, tol)
+}
+
+// Equal returns whether this set and another set are numerically equivalent; i.e. whether they have
+// the same cell kind, space dimension and number of points, and whether the points are equal
+// regardless of their order (see QuadPointsMatch). The names of the sets are not compared
+//   tol -- tolerance for the coordinates and weights
+func (o *IntPointsSet) Equal(other *IntPointsSet, tol float64) bool {
+	if o.Kind != other.Kind || o.Ndim != other.Ndim || o.Npts != other.Npts {
+		return false
+	}
+	return QuadPointsMatch(o.P, other.P, tol)
+}
+
+// CheckSymmetry returns whether this set is invariant under the symmetries of the reference cell.
+// See QuadPointsCheckSymmetry
+func (o *IntPointsSet) CheckSymmetry(tol float64) bool {
+	return QuadPointsCheckSymmetry(o.P, o.Kind, tol)
+}
+
+// CheckExactness checks whether this set integrates exactly all monomials up to a given total
+// degree. See QuadPointsCheckExactness
+func (o *IntPointsSet) CheckExactness(degree int, tol float64) (err error) {
+	return QuadPointsCheckExactness(o.P, o.Kind, degree, tol)
+}
+
+// HasNegativeWeights returns whether this set has at least one negative weight or not
+func (o *IntPointsSet) HasNegativeWeights() bool {
+	return QuadPointsHasNegativeWeights(o.P)
+}
+
+// SplitByWeightSign returns two new sets with the points of this set with positive (or zero) and
+// negative weights, respectively. The names are suffixed with "-positive" and "-negative". See
+// QuadPointsSplitByWeightSign
+func (o *IntPointsSet) SplitByWeightSign() (positive, negative *IntPointsSet) {
+	pp, pn := QuadPointsSplitByWeightSign(o.P)
+	positive, negative = new(IntPointsSet), new(IntPointsSet)
+	*positive, *negative = *o, *o
+	positive.Name, positive.Npts, positive.P = o.Name+"-positive", len(pp), pp
+	negative.Name, negative.Npts, negative.P = o.Name+"-negative", len(pn), pn
+	return
+}
+
+// Shape returns the name of the shape of the reference cell of this set; e.g. "tri" or "qua" for
+// sets with ndim = 2. See kindShapes
+func (o *IntPointsSet) Shape() string {
+	if o.Kind < 0 || o.Kind >= KindNumMax {
+		chk.Panic("cellKind = %d is invalid\n", o.Kind)
+	}
+	return kindShapes[o.Kind]
+}
+
+// WarnIfInsufficient returns an error if this set cannot integrate exactly all polynomials of a
+// given degree over the reference cell; e.g. the 1-point set for the stiffness of qua8 elements
+// (under-integration). The error message suggests the set selected by IntPointsSelectSet
+//   integrandDegree -- polynomial degree of the integrand
+//   NOTE: the degree of this set is measured by brute force (see QuadPointsExactDegree); thus,
+//         custom sets are also checked, but the points must be within the reference cell
+func (o *IntPointsSet) WarnIfInsufficient(integrandDegree int) (err error) {
+	degree := QuadPointsExactDegree(o.P, o.Kind, integrandDegree)
+	if degree >= integrandDegree {
+		return
+	}
+	suggestion := "no set is available"
+	if setName, e := IntPointsSelectSet(o.Kind, integrandDegree); e == nil {
+		suggestion = io.Sf("consider the set %q", setName)
+	}
+	return chk.Err("set %q for %s cells integrates exactly polynomials of degree up to %d only; thus, integrands of degree %d are under-integrated: %s\n",
+		o.Name, kindShapes[o.Kind], degree, integrandDegree, suggestion)
+}
+
+// Degree returns the polynomial degree integrated exactly by this set
+//   NOTE: the set must be one of the sets in the database of integration points (see GetIntPoints)
+func (o *IntPointsSet) Degree() int {
+	return IntPointsFindDegree(o.Kind, o.Name)
+}
+
+// EstimateError returns a heuristic estimate of the error of integrating f with this set computed
+// as |I_n - I_{n-1}|, where I_n is the integral computed with this set and I_{n-1} is the integral
+// computed with the Gauss-Legendre set with one point less along each direction
+//   NOTE: (1) this set must be a Gauss-Legendre set for lin, qua or hex; e.g. "legendre_9"
+//         (2) the estimate is usually pessimistic for smooth functions since the error of I_n
+//             is much smaller than the error of I_{n-1}; nonetheless, it is not a bound.
+//             See NewIntPointsSetsKronrod for a more reliable alternative
+func (o *IntPointsSet) EstimateError(f fun.Sv) (estimate float64, err error) {
+	if o.Kind != KindLin && o.Kind != KindQua && o.Kind != KindHex {
+		return 0, chk.Err("error can only be estimated with sets for lin, qua or hex. cellKind = %d is invalid\n", o.Kind)
+	}
+	npts, e := strconv.Atoi(strings.TrimPrefix(o.Name, "legendre_"))
+	if !strings.HasPrefix(o.Name, "legendre_") || e != nil || npts != o.Npts {
+		return 0, chk.Err("error can only be estimated with Gauss-Legendre sets. %q is invalid\n", o.Name)
+	}
+	n1d := int(math.Floor(math.Pow(float64(npts), 1.0/float64(o.Ndim)) + 0.5))
+	if n1d < 2 {
+		return 0, chk.Err("error cannot be estimated with %q because it has only one point along each direction\n", o.Name)
+	}
+	nlow := int(math.Pow(float64(n1d-1), float64(o.Ndim)) + 0.5)
+	res := QuadPointsIntegrate(o.P, o.Ndim, f)
+	low := QuadPointsIntegrate(QuadPointsGaussLegendre(o.Ndim, nlow), o.Ndim, f)
+	return math.Abs(res - low), nil
+}
+
+// UnmarshalJSON unmarshals and checks the consistency of a set of integration points
+func (o *IntPointsSet) UnmarshalJSON(b []byte) (err error) {
+	type auxiliary IntPointsSet
+	var a auxiliary
+	err = json.Unmarshal(b, &a)
+	if err != nil {
+		return
+	}
+	p := IntPointsSet(a)
+	err = p.check()
+	if err != nil {
+		return
+	}
+	*o = p
+	return
+}
+
+// Save saves this set to a file using the gob encoding; the directory is created if necessary
+func (o *IntPointsSet) Save(filename string) {
+	var b bytes.Buffer
+	err := gob.NewEncoder(&b).Encode(o)
+	if err != nil {
+		chk.Panic("cannot encode set of integration points:\n%v\n", err)
+	}
+	io.WriteBytesToFileD(filepath.Dir(filename), filepath.Base(filename), b.Bytes())
+}
+
+// Load loads and checks the consistency of a set previously saved with Save
+func (o *IntPointsSet) Load(filename string) {
+	var p IntPointsSet
+	err := gob.NewDecoder(bytes.NewReader(io.ReadFile(filename))).Decode(&p)
+	if err != nil {
+		chk.Panic("cannot decode set of integration points in file <%s>:\n%v\n", filename, err)
+	}
+	err = p.check()
+	if err != nil {
+		chk.Panic("set of integration points in file <%s> is inconsistent:\n%v", filename, err)
+	}
+	*o = p
+}
+
+// SaveTable saves this set to a text file with the table format of io.ReadTable; the directory is
+// created if necessary. The metadata are written as comments before the table; e.g.
+//   # shape hex
+//   # name legendre_27
+//   # ndim 3
+//   # npts 27
+//   r s t w
+//   -7.7459666924148340e-01 -7.7459666924148340e-01 -7.7459666924148340e-01 1.7146776406035665e-01
+//   ...
+//   NOTE: only ndim coordinates are written
+func (o *IntPointsSet) SaveTable(filename string) {
+	var b bytes.Buffer
+	io.Ff(&b, "# shape %s\n", o.Shape())
+	io.Ff(&b, "# name %s\n", o.Name)
+	io.Ff(&b, "# ndim %d\n", o.Ndim)
+	io.Ff(&b, "# npts %d\n", o.Npts)
+	if o.Source != "" {
+		io.Ff(&b, "# source %s\n", o.Source)
+	}
+	if o.RecommendedFor != "" {
+		io.Ff(&b, "# recommendedFor %s\n", o.RecommendedFor)
+	}
+	io.Ff(&b, "%s w\n", strings.Join([]string{"r", "s", "t"}[:o.Ndim], " "))
+	for _, p := range o.P {
+		for j := 0; j < o.Ndim; j++ {
+			io.Ff(&b, "%.16e ", p[j])
+		}
+		io.Ff(&b, "%.16e\n", p[3])
+	}
+	io.WriteFileD(filepath.Dir(filename), filepath.Base(filename), &b)
+}
+
+// LoadTable loads and checks the consistency of a set previously saved with SaveTable
+func (o *IntPointsSet) LoadTable(filename string) {
+	var p IntPointsSet
+	shape := ""
+	io.ReadLines(filename, func(idx int, line string) (stop bool) {
+		r := strings.Fields(line)
+		if len(r) < 3 || r[0] != "#" {
+			return
+		}
+		value := strings.TrimSpace(strings.SplitN(line, r[1], 2)[1])
+		switch r[1] {
+		case "shape":
+			shape = value
+		case "name":
+			p.Name = value
+		case "ndim":
+			p.Ndim = io.Atoi(value)
+		case "npts":
+			p.Npts = io.Atoi(value)
+		case "source":
+			p.Source = value
+		case "recommendedFor":
+			p.RecommendedFor = value
+		}
+		return
+	})
+	p.Kind = -1
+	for kind, name := range kindShapes {
+		if shape == name {
+			p.Kind = kind
+		}
+	}
+	keys, T := io.ReadTable(filename)
+	if len(keys) < 2 || keys[len(keys)-1] != "w" {
+		chk.Panic("table in file <%s> must have coordinates and a column named \"w\" with the weights\n", filename)
+	}
+	p.P = make([][]float64, len(T["w"]))
+	for i := range p.P {
+		p.P[i] = make([]float64, 4)
+		for j, key := range keys[:len(keys)-1] {
+			if j > 2 {
+				chk.Panic("table in file <%s> has too many coordinates\n", filename)
+			}
+			p.P[i][j] = T[key][i]
+		}
+		p.P[i][3] = T["w"][i]
+	}
+	err := p.check()
+	if err != nil {
+		chk.Panic("set of integration points in file <%s> is inconsistent:\n%v", filename, err)
+	}
+	if len(keys)-1 != p.Ndim {
+		chk.Panic("table in file <%s> has %d coordinates but ndim=%d\n", filename, len(keys)-1, p.Ndim)
+	}
+	*o = p
+}
+
+// check checks the consistency of this set
+func (o *IntPointsSet) check() (err error) {
+	if o.Ndim < 1 || o.Ndim > 3 {
+		return chk.Err("ndim=%d is invalid. ndim must be 1, 2, or 3\n", o.Ndim)
+	}
+	if o.Kind < 0 || o.Kind >= KindNumMax {
+		return chk.Err("cellKind = %d is invalid\n", o.Kind)
+	}
+	if o.Ndim != kindNdim(o.Kind) {
+		return chk.Err("ndim=%d is incompatible with cellKind = %d\n", o.Ndim, o.Kind)
+	}
+	if len(o.P) != o.Npts {
+		return chk.Err("number of points %d is different than npts=%d\n", len(o.P), o.Npts)
+	}
+	for i, p := range o.P {
+		if len(p) != 4 {
+			return chk.Err("point %d must have 4 values (r,s,t,w). %d is invalid\n", i, len(p))
+		}
+		for j := o.Ndim; j < 3; j++ {
+			if p[j] != 0 {
+				return chk.Err("coordinate %d of point %d must be zero because ndim=%d\n", j, i, o.Ndim)
+			}
+		}
+	}
+	return
+}
+
+// WriteCSV writes the points of this set in CSV format with the header x0,...,weight; only
+// ndim coordinates are written
+func (o *IntPointsSet) WriteCSV(w goio.Writer) (err error) {
+	c := csv.NewWriter(w)
+	header := make([]string, o.Ndim+1)
+	for j := 0; j < o.Ndim; j++ {
+		header[j] = io.Sf("x%d", j)
+	}
+	header[o.Ndim] = "weight"
+	c.Write(header)
+	row := make([]string, o.Ndim+1)
+	for _, p := range o.P {
+		for j := 0; j < o.Ndim; j++ {
+			row[j] = strconv.FormatFloat(p[j], 'g', -1, 64)
+		}
+		row[o.Ndim] = strconv.FormatFloat(p[3], 'g', -1, 64)
+		c.Write(row)
+	}
+	c.Flush()
+	return c.Error()
+}
+
+// Draw draws the points of this set within the outline of the reference cell; e.g. the reference
+// triangle for tri or the reference tetrahedron for tet. See QuadPointDraw
+//   dx -- can be used to displace the reference cell; may be nil
+func (o *IntPointsSet) Draw(dx []float64, args *plt.A) {
+	quadPointDrawCell(o.P, o.Kind, dx, args)
+}
+
+// IntPointsDrawCompare draws the points of two sets within the outline of their reference cell; e.g.
+// to compare "wilson5corner_5" with "wilson5stable_5"
+//   dx           -- can be used to displace the reference cell; may be nil
+//   argsA, argsB -- arguments for the points of a and b; may be nil
+//   NOTE: the cell kinds (and thus the space dimensions) of both sets must be equal
+func IntPointsDrawCompare(a, b *IntPointsSet, dx []float64, argsA, argsB *plt.A) (err error) {
+	if a.Kind != b.Kind {
+		return chk.Err("cannot compare set with cellKind = %d (ndim=%d) to set with cellKind = %d (ndim=%d)\n", a.Kind, a.Ndim, b.Kind, b.Ndim)
+	}
+	if argsA == nil {
+		argsA = &plt.A{C: "r", M: "*", Mec: "r", L: a.Name, NoClip: true}
+	}
+	if argsB == nil {
+		argsB = &plt.A{C: "b", M: "o", Void: true, Ms: 8, L: b.Name, NoClip: true}
+	}
+	a.Draw(dx, argsA)
+	b.Draw(dx, argsB)
+	return
+}
+
+// String returns a table with the integration points
+func (o *IntPointsSet) String() (l string) {
+	l = io.Sf("kind = %d, name = %q, ndim = %d, npts = %d\n", o.Kind, o.Name, o.Ndim, o.Npts)
+	for i, p := range o.P {
+		l += io.Sf("%4d:", i)
+		for j := 0; j < o.Ndim; j++ {
+			l += io.Sf(" %23.15e", p[j])
+		}
+		l += io.Sf(" | %23.15e\n", p[3])
+	}
+	return
+}
+
+// IntPointsReport holds a summary of the properties of a set of integration points
+type IntPointsReport struct {
+	Kind            int     // cell kind
+	Name            string  // name of set
+	Npts            int     // number of points
+	Degree          int     // declared polynomial degree (from database); -1 if unknown
+	ExactDegree     int     // polynomial degree measured by brute force (see QuadPointsExactDegree)
+	SumWeights      float64 // sum of weights
+	Measure         float64 // measure of reference cell
+	Wmin            float64 // minimum weight
+	Wmax            float64 // maximum weight
+	NegativeWeights bool    // at least one weight is negative
+	AllInside       bool    // all points are inside the reference cell
+}
+
+// Report returns a summary of the properties of this set
+//   degreeMax -- maximum degree to be checked by brute force
+//   tol       -- tolerance to consider points on the boundary of the reference cell
+func (o *IntPointsSet) Report(degreeMax int, tol float64) (r *IntPointsReport) {
+	r = &IntPointsReport{Kind: o.Kind, Name: o.Name, Npts: o.Npts, Degree: -1}
+	degree, ok := getIntPointsDegree()[o.Kind][o.Name]
+	if _, inDb := GetIntPoints()[o.Kind][o.Name]; !inDb {
+		_, degree, ok = intPointsGenerate(o.Kind, o.Name)
+	}
+	if ok {
+		r.Degree = degree
+	}
+	r.ExactDegree = QuadPointsExactDegree(o.P, o.Kind, degreeMax)
+	r.SumWeights = QuadPointsSumWeights(o.P)
+	r.Measure, _ = ReferenceMeasure(o.Kind)
+	for i, p := range o.P {
+		if i == 0 || p[3] < r.Wmin {
+			r.Wmin = p[3]
+		}
+		if i == 0 || p[3] > r.Wmax {
+			r.Wmax = p[3]
+		}
+	}
+	r.NegativeWeights = r.Wmin < 0
+	r.AllInside = o.AllInReferenceCell(tol)
+	return
+}
+
+// String returns a string representation of this report
+func (o *IntPointsReport) String() (l string) {
+	l = io.Sf("kind = %d, name = %q, npts = %d\n", o.Kind, o.Name, o.Npts)
+	l += io.Sf("  degree (declared) = %d\n", o.Degree)
+	l += io.Sf("  degree (measured) = %d\n", o.ExactDegree)
+	l += io.Sf("  sum of weights    = %v (measure = %v)\n", o.SumWeights, o.Measure)
+	l += io.Sf("  min/max weight    = %v / %v\n", o.Wmin, o.Wmax)
+	l += io.Sf("  negative weights  = %v\n", o.NegativeWeights)
+	l += io.Sf("  all inside cell   = %v\n", o.AllInside)
+	return
+}
+
+// ReferenceMeasure returns the measure (length, area or volume) of the reference cell of cell kind;
+// i.e. the sum of weights of all quadrature rules over this cell
+func ReferenceMeasure(cellKind int) (measure float64, err error) {
+	switch cellKind {
+	case KindLin:
+		return 2, nil
+	case KindTri:
+		return 1.0 / 2.0, nil
+	case KindQua:
+		return 4, nil
+	case KindTet:
+		return 1.0 / 6.0, nil
+	case KindHex:
+		return 8, nil
+	case KindWed:
+		return 1, nil
+	case KindPyr:
+		return 4.0 / 3.0, nil
+	}
+	return 0, chk.Err("cellKind = %d is invalid\n", cellKind)
+}
+
+// kindShapes holds the names of the shapes of reference cells [KindNumMax]
+var kindShapes = []string{
+	KindLin: "lin",
+	KindTri: "tri",
+	KindQua: "qua",
+	KindTet: "tet",
+	KindHex: "hex",
+	KindWed: "wed",
+	KindPyr: "pyr",
+}
+
+// kindNdim returns the space dimension of cell kind
+func kindNdim(cellKind int) int {
+	switch cellKind {
+	case KindLin:
+		return 1
+	case KindTri, KindQua:
+		return 2
+	case KindTet, KindHex, KindWed, KindPyr:
+		return 3
+	}
+	chk.Panic("cellKind = %d is invalid\n", cellKind)
+	return 0
+}
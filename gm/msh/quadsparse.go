@@ -0,0 +1,188 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package msh
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+)
+
+// QuadPointsSmolyak generates the points of a Smolyak sparse grid over the reference hypercube
+// [-1,1]^ndim using nested Clenshaw-Curtis rules
+//   Input:
+//     ndim  -- space dimension; ndim ≥ 1 (may be greater than 3)
+//     level -- level of the sparse grid; level ≥ 0. level=0 yields the centre point only
+//   Output:
+//     X -- coordinates [npts][ndim]
+//     W -- weights [npts]. NOTE: some weights may be negative
+//   NOTE: (1) polynomials of total degree up to 2⋅level+1 are integrated exactly
+//         (2) the number of points grows much slower than the number of points of tensor-product
+//             rules; e.g. with ndim=4 and level=3, there are 137 points
+//         (3) points shared by more than one tensor product are merged (the rules are nested)
+//   Reference:
+//   [1] Gerstner T, Griebel M (1998) Numerical integration using sparse grids. Numerical
+//       Algorithms, 18:209-232
+func QuadPointsSmolyak(ndim, level int) (X [][]float64, W []float64) {
+	if ndim < 1 {
+		chk.Panic("space dimension of sparse grid must be at least 1. ndim=%d is invalid\n", ndim)
+	}
+	if level < 0 {
+		chk.Panic("level of sparse grid must be non-negative. level=%d is invalid\n", level)
+	}
+
+	// finest 1D grid: points are identified by their index in this grid
+	lmax := level + 1
+	xfine := quadPointsClenshawCurtisX(quadPointsClenshawCurtisM(lmax))
+	nfine := len(xfine)
+
+	// 1D rules for all levels; indices refer to the finest grid
+	idx := make([][]int, lmax+1)
+	wts := make([][]float64, lmax+1)
+	for l := 1; l <= lmax; l++ {
+		m := quadPointsClenshawCurtisM(l)
+		wts[l] = quadPointsClenshawCurtisW(m)
+		idx[l] = make([]int, m)
+		for j := 0; j < m; j++ {
+			if m == 1 {
+				idx[l][j] = (nfine - 1) / 2
+			} else {
+				idx[l][j] = j * (nfine - 1) / (m - 1)
+			}
+		}
+	}
+
+	// combination technique: sum over multi-indices i with q-ndim+1 ≤ |i| ≤ q
+	q := level + ndim
+	weights := make(map[string]float64)
+	keys := make(map[string][]int)
+	lvl := make([]int, ndim)
+	quadPointsMultiIndex(lvl, 0, q-ndim+1, q, lmax, func(sumL int) {
+		coef := math.Pow(-1, float64(q-sumL)) * fun.Binomial(ndim-1, q-sumL)
+		pos := make([]int, ndim) // position within each 1D rule
+		for {
+			w := coef
+			key := make([]int, ndim)
+			for d := 0; d < ndim; d++ {
+				w *= wts[lvl[d]][pos[d]]
+				key[d] = idx[lvl[d]][pos[d]]
+			}
+			k := quadPointsSparseKey(key)
+			weights[k] += w
+			keys[k] = key
+			d := 0
+			for ; d < ndim; d++ {
+				pos[d]++
+				if pos[d] < len(idx[lvl[d]]) {
+					break
+				}
+				pos[d] = 0
+			}
+			if d == ndim {
+				break
+			}
+		}
+	})
+
+	// collect points in a deterministic order
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+	X = make([][]float64, 0, len(sorted))
+	W = make([]float64, 0, len(sorted))
+	for _, k := range sorted {
+		if weights[k] == 0 {
+			continue // cancelled out by the combination technique
+		}
+		x := make([]float64, ndim)
+		for d, i := range keys[k] {
+			x[d] = xfine[i]
+		}
+		X = append(X, x)
+		W = append(W, weights[k])
+	}
+	return
+}
+
+// quadPointsMultiIndex loops over all multi-indices lvl (1 ≤ lvl[d] ≤ lmax) with sumMin ≤ |lvl| ≤ sumMax
+func quadPointsMultiIndex(lvl []int, d, sumMin, sumMax, lmax int, callback func(sumL int)) {
+	sum := 0
+	for i := 0; i < d; i++ {
+		sum += lvl[i]
+	}
+	if d == len(lvl) {
+		if sum >= sumMin && sum <= sumMax {
+			callback(sum)
+		}
+		return
+	}
+	for l := 1; l <= lmax && sum+l+(len(lvl)-d-1) <= sumMax; l++ {
+		lvl[d] = l
+		quadPointsMultiIndex(lvl, d+1, sumMin, sumMax, lmax, callback)
+	}
+}
+
+// quadPointsSparseKey returns a key identifying a point of the sparse grid
+func quadPointsSparseKey(key []int) string {
+	b := make([]byte, 0, 4*len(key))
+	for _, k := range key {
+		b = append(b, byte(k>>24), byte(k>>16), byte(k>>8), byte(k))
+	}
+	return string(b)
+}
+
+// quadPointsClenshawCurtisM returns the number of points of the nested Clenshaw-Curtis rule of level l ≥ 1
+func quadPointsClenshawCurtisM(l int) int {
+	if l == 1 {
+		return 1
+	}
+	return (1 << uint(l-1)) + 1
+}
+
+// quadPointsClenshawCurtisX returns the positions of the m-point Clenshaw-Curtis rule over [-1,1]
+func quadPointsClenshawCurtisX(m int) (x []float64) {
+	x = make([]float64, m)
+	if m == 1 {
+		return
+	}
+	for j := 0; j < m; j++ {
+		if 2*j == m-1 {
+			continue // centre
+		}
+		x[j] = -math.Cos(math.Pi * float64(j) / float64(m-1))
+	}
+	return
+}
+
+// quadPointsClenshawCurtisW returns the weights of the m-point Clenshaw-Curtis rule over [-1,1]
+func quadPointsClenshawCurtisW(m int) (w []float64) {
+	w = make([]float64, m)
+	if m == 1 {
+		w[0] = 2
+		return
+	}
+	n := m - 1
+	for j := 0; j <= n; j++ {
+		θ := math.Pi * float64(j) / float64(n)
+		sum := 0.0
+		for k := 1; k <= n/2; k++ {
+			b := 2.0
+			if 2*k == n {
+				b = 1.0
+			}
+			sum += b / float64(4*k*k-1) * math.Cos(2.0*float64(k)*θ)
+		}
+		c := 2.0
+		if j == 0 || j == n {
+			c = 1.0
+		}
+		w[j] = c / float64(n) * (1.0 - sum)
+	}
+	return
+}
@@ -0,0 +1,970 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package msh
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/utl"
+)
+
+// QuadPointsIntegrate integrates scalar function of vector argument over the reference cell
+//
+//   Computes:
+//
+//           ⌠⌠⌠   →         nip-1   →
+//     res = │││ f(r) dΩr ≈   Σ   f(ri)⋅wi
+//           ⌡⌡⌡             i=0
+//              Ωr
+//   Input:
+//     pts  -- quadrature points [npts][4] where 4 means r,s,t,w
+//     ndim -- space dimension = number of coordinates passed to f
+//     f    -- integrand function
+func QuadPointsIntegrate(pts [][]float64, ndim int, f fun.Sv) (res float64) {
+	for _, p := range pts {
+		res += f(p[:ndim]) * p[3]
+	}
+	return
+}
+
+// QuadPointsIntegrateValues computes the weighted sum of values of a function previously computed
+// at the quadrature points; i.e. no function is called. This is useful in hot loops where the
+// integrand is evaluated in batch (e.g. using the coordinates from QuadPointsToCoords)
+//   Input:
+//     pts    -- quadrature points [npts][4] where 4 means r,s,t,w
+//     values -- values of the integrand at each point [npts], in the same order of pts
+func QuadPointsIntegrateValues(pts [][]float64, values []float64) (res float64) {
+	if len(values) != len(pts) {
+		chk.Panic("number of values must be equal to the number of points. %d != %d\n", len(values), len(pts))
+	}
+	for i, p := range pts {
+		res += values[i] * p[3]
+	}
+	return
+}
+
+// QuadPointsIntegrateComplex integrates complex-valued function of vector argument over the
+// reference cell. The weights are real. See QuadPointsIntegrate
+//   Input:
+//     pts  -- quadrature points [npts][4] where 4 means r,s,t,w
+//     ndim -- space dimension = number of coordinates passed to f
+//     f    -- integrand function
+func QuadPointsIntegrateComplex(pts [][]float64, ndim int, f func(x la.Vector) complex128) (res complex128) {
+	for _, p := range pts {
+		res += f(p[:ndim]) * complex(p[3], 0)
+	}
+	return
+}
+
+// QuadPointsIntegrateVector integrates vector function of vector argument over the reference cell
+//
+//   Computes:
+//
+//           ⌠⌠⌠ →  →         nip-1 →  →
+//     res = │││ f( r ) dΩr ≈   Σ   f( ri )⋅wi
+//           ⌡⌡⌡               i=0
+//              Ωr
+//   Input:
+//     pts  -- quadrature points [npts][4] where 4 means r,s,t,w
+//     ndim -- space dimension = number of coordinates passed to f
+//     n    -- length of vector function; e.g. number of basis functions
+//     f    -- integrand function: f(fx, x) computes fx = f(x) with len(fx) = n
+//   Output:
+//     res -- the integral [n]
+func QuadPointsIntegrateVector(pts [][]float64, ndim, n int, f fun.Vv) (res la.Vector) {
+	if n < 1 {
+		chk.Panic("length of vector function must be at least 1. n=%d is invalid\n", n)
+	}
+	res = la.NewVector(n)
+	fx := la.NewVector(n)
+	for _, p := range pts {
+		fx.Fill(0)
+		f(fx, p[:ndim])
+		for j := 0; j < n; j++ {
+			res[j] += fx[j] * p[3]
+		}
+	}
+	return
+}
+
+// QuadPointsIntegrateMatrix integrates matrix function of vector argument over the reference cell;
+// e.g. to compute mass or stiffness matrices
+//
+//   Computes:
+//
+//           ⌠⌠⌠   →         nip-1   →
+//     res = │││ F(r) dΩr ≈   Σ   F(ri)⋅wi
+//           ⌡⌡⌡             i=0
+//              Ωr
+//   Input:
+//     pts  -- quadrature points [npts][4] where 4 means r,s,t,w
+//     ndim -- space dimension = number of coordinates passed to f
+//     n    -- number of rows and columns of matrix function; e.g. number of basis functions
+//     f    -- integrand function: f(F, x) computes F = F(x) with F being n×n
+//   Output:
+//     res -- the integral [n][n]
+func QuadPointsIntegrateMatrix(pts [][]float64, ndim, n int, f fun.Mv) (res *la.Matrix) {
+	if n < 1 {
+		chk.Panic("size of matrix function must be at least 1. n=%d is invalid\n", n)
+	}
+	res = la.NewMatrix(n, n)
+	F := la.NewMatrix(n, n)
+	for _, p := range pts {
+		F.Fill(0)
+		f(F, p[:ndim])
+		for k, v := range F.Data {
+			res.Data[k] += v * p[3]
+		}
+	}
+	return
+}
+
+// QuadPointsIntegratePar integrates scalar function of vector argument over the reference cell
+// by evaluating f concurrently; e.g. when f is expensive to evaluate
+//   Input:
+//     pts      -- quadrature points [npts][4] where 4 means r,s,t,w
+//     ndim     -- space dimension = number of coordinates passed to f
+//     f        -- integrand function; it must be safe to be called concurrently
+//     nWorkers -- number of goroutines; the points are split into nWorkers contiguous chunks
+//   NOTE: the values of f are summed in the same order as in QuadPointsIntegrate; thus the
+//         results are identical
+func QuadPointsIntegratePar(pts [][]float64, ndim int, f fun.Sv, nWorkers int) (res float64) {
+	if nWorkers < 1 {
+		chk.Panic("number of workers must be at least 1. nWorkers=%d is invalid\n", nWorkers)
+	}
+	vals := make([]float64, len(pts))
+	size := (len(pts) + nWorkers - 1) / nWorkers
+	wg := new(sync.WaitGroup)
+	for start := 0; start < len(pts); start += size {
+		end := utl.Imin(start+size, len(pts))
+		wg.Add(1)
+		go func(start, end int) {
+			for i := start; i < end; i++ {
+				vals[i] = f(pts[i][:ndim])
+			}
+			wg.Done()
+		}(start, end)
+	}
+	wg.Wait()
+	for i, p := range pts {
+		res += vals[i] * p[3]
+	}
+	return
+}
+
+// QuadPointsIntegrateJ integrates scalar function of vector argument over the reference cell
+// considering the determinant of the Jacobian of a mapping
+//
+//   Computes:
+//
+//           ⌠⌠⌠   →    →          nip-1   →      →
+//     res = │││ f(r)⋅J(r) dΩr ≈    Σ   f(ri)⋅J(ri)⋅wi
+//           ⌡⌡⌡                   i=0
+//              Ωr
+//   Input:
+//     pts  -- quadrature points [npts][4] where 4 means r,s,t,w
+//     ndim -- space dimension = number of coordinates passed to f and detJ
+//     f    -- integrand function
+//     detJ -- determinant of the Jacobian of the mapping
+func QuadPointsIntegrateJ(pts [][]float64, ndim int, f, detJ fun.Sv) (res float64) {
+	for _, p := range pts {
+		res += f(p[:ndim]) * detJ(p[:ndim]) * p[3]
+	}
+	return
+}
+
+// QuadPointsIntegrateCurved integrates scalar function over a (curved) physical cell given by a
+// mapping from the reference cell; the Jacobian of the mapping is computed numerically by means of
+// central differences; i.e. only the mapping has to be provided
+//
+//   Computes:
+//
+//           ⌠⌠⌠   →        nip-1     →       →
+//     res = │││ f(x) dΩx ≈   Σ   f(x(ri))⋅|J(ri)|⋅wi    with   Jkj = ∂xk/∂rj
+//           ⌡⌡⌡             i=0
+//              Ωx
+//   Input:
+//     pts   -- quadrature points [npts][4] where 4 means r,s,t,w
+//     ndim  -- space dimension = number of reference and physical coordinates
+//     f     -- integrand function of the physical coordinates
+//     mapFn -- mapping from reference to physical coordinates; e.g. of an isoparametric element
+//     h     -- step of the central differences; e.g. 1e-6
+//   NOTE: (1) the mapping is evaluated at points at a distance h from the integration points;
+//             thus, it must be defined slightly outside the reference cell if the points are on
+//             its boundary
+//         (2) mapFn is always called with a new slice and its result is copied; thus, mapFn may
+//             return (or modify) its argument or reuse an internal buffer
+//         (3) a panic occurs if |det(J)| < 1e-14 at any point
+func QuadPointsIntegrateCurved(pts [][]float64, ndim int, f func(phys []float64) float64, mapFn func(ref []float64) []float64, h float64) (res float64) {
+	if ndim < 1 || ndim > 3 {
+		chk.Panic("ndim=%d is invalid. ndim must be 1, 2, or 3\n", ndim)
+	}
+	if h <= 0 {
+		chk.Panic("step of central differences must be positive. h = %g is invalid\n", h)
+	}
+	mapAt := func(p []float64, j int, δ float64) (x []float64) { // copy of x(r) with r = p + δ⋅e_j
+		r := make([]float64, ndim)
+		copy(r, p[:ndim])
+		r[j] += δ
+		phys := mapFn(r)
+		if len(phys) < ndim {
+			chk.Panic("mapping function must return %d physical coordinates. %d is invalid\n", ndim, len(phys))
+		}
+		x = make([]float64, ndim)
+		copy(x, phys)
+		return
+	}
+	J := make([][]float64, ndim)
+	for k := 0; k < ndim; k++ {
+		J[k] = make([]float64, ndim)
+	}
+	for _, p := range pts {
+		for j := 0; j < ndim; j++ {
+			xp := mapAt(p, j, +h)
+			xm := mapAt(p, j, -h)
+			for k := 0; k < ndim; k++ {
+				J[k][j] = (xp[k] - xm[k]) / (2.0 * h)
+			}
+		}
+		detJ := quadPointsDet(J)
+		if math.Abs(detJ) < 1e-14 {
+			chk.Panic("determinant of the Jacobian of the mapping is zero at point %v: |det(J)| = %g < 1e-14\n", p[:ndim], math.Abs(detJ))
+		}
+		res += f(mapAt(p, 0, 0)) * math.Abs(detJ) * p[3]
+	}
+	return
+}
+
+// quadPointsDet returns the determinant of a 1×1, 2×2 or 3×3 matrix
+func quadPointsDet(a [][]float64) float64 {
+	switch len(a) {
+	case 1:
+		return a[0][0]
+	case 2:
+		return a[0][0]*a[1][1] - a[0][1]*a[1][0]
+	}
+	return a[0][0]*(a[1][1]*a[2][2]-a[1][2]*a[2][1]) - a[0][1]*(a[1][0]*a[2][2]-a[1][2]*a[2][0]) + a[0][2]*(a[1][0]*a[2][1]-a[1][1]*a[2][0])
+}
+
+// IntPointsCachedJ integrates functions over a cell with precomputed determinants of the Jacobian
+// at the integration points; e.g. to reuse geometric factors in many assembly steps where only the
+// values of the integrand change
+//
+//   Computes:
+//
+//           nip-1
+//     res =   Σ   fi⋅Ji⋅wi
+//            i=0
+//
+type IntPointsCachedJ struct {
+	Npts  int       // number of integration points
+	Coefs []float64 // products of weights and determinants of the Jacobian wi⋅Ji [npts]
+}
+
+// NewIntPointsCachedJ returns a new object to integrate with precomputed determinants of the Jacobian
+//   pts  -- quadrature points [npts][4] where 4 means r,s,t,w
+//   detJ -- determinants of the Jacobian at each point [npts]
+func NewIntPointsCachedJ(pts [][]float64, detJ []float64) (o *IntPointsCachedJ) {
+	if len(detJ) != len(pts) {
+		chk.Panic("number of determinants must be equal to the number of points. %d != %d\n", len(detJ), len(pts))
+	}
+	o = new(IntPointsCachedJ)
+	o.Npts = len(pts)
+	o.Coefs = make([]float64, o.Npts)
+	for i, p := range pts {
+		o.Coefs[i] = p[3] * detJ[i]
+	}
+	return
+}
+
+// Integrate computes the integral given the values of the integrand at each point
+//   fvals -- values of integrand fi = f(xi(ri)) [npts]
+func (o *IntPointsCachedJ) Integrate(fvals []float64) (res float64) {
+	if len(fvals) != o.Npts {
+		chk.Panic("number of values must be equal to the number of points. %d != %d\n", len(fvals), o.Npts)
+	}
+	for i, c := range o.Coefs {
+		res += fvals[i] * c
+	}
+	return
+}
+
+// IntPointsAccumulator integrates values of a function streamed one at a time in the order of
+// the quadrature points; e.g. values computed by an external process
+type IntPointsAccumulator struct {
+	W     []float64 // weights [npts]
+	count int       // number of values added so far
+	sum   float64   // weighted sum of values added so far
+}
+
+// NewIntPointsAccumulator returns a new object to integrate streamed values
+//   pts -- quadrature points [npts][4] where 4 means r,s,t,w
+func NewIntPointsAccumulator(pts [][]float64) (o *IntPointsAccumulator) {
+	o = new(IntPointsAccumulator)
+	o.W = make([]float64, len(pts))
+	for i, p := range pts {
+		o.W[i] = p[3]
+	}
+	return
+}
+
+// Add adds the value of the integrand at the next quadrature point
+func (o *IntPointsAccumulator) Add(value float64) {
+	if o.count < len(o.W) {
+		o.sum += value * o.W[o.count]
+	}
+	o.count++
+}
+
+// Count returns the number of values added so far
+func (o *IntPointsAccumulator) Count() int {
+	return o.count
+}
+
+// Result returns the integral after the values at all quadrature points have been added
+//   err -- an error is returned if the number of values is different than the number of points
+func (o *IntPointsAccumulator) Result() (res float64, err error) {
+	if o.count != len(o.W) {
+		return 0, chk.Err("number of values (%d) must be equal to the number of points (%d)\n", o.count, len(o.W))
+	}
+	return o.sum, nil
+}
+
+// Reset clears the values added so far
+func (o *IntPointsAccumulator) Reset() {
+	o.count = 0
+	o.sum = 0
+}
+
+// QuadPointsIntegrateAdaptive integrates scalar function of vector argument over the reference
+// cell by recursively subdividing the cell until the estimated error is smaller than tol
+//   Input:
+//     f         -- integrand function
+//     cellKind  -- kind of reference cell: KindLin, KindQua or KindHex
+//     tol       -- absolute tolerance; each subcell is given a share of tol proportional to its size
+//     maxSubdiv -- maximum number of subdivision levels
+//   Output:
+//     res -- the integral
+//     err -- an error is returned if tol could not be met with maxSubdiv levels of subdivision;
+//            res is still the best available estimate in this case
+//   NOTE: the error in each subcell is estimated by comparing the results of two Gauss-Legendre
+//         rules with 3 and 4 points along each direction
+func QuadPointsIntegrateAdaptive(f fun.Sv, cellKind int, tol float64, maxSubdiv int) (res float64, err error) {
+	var ndim int
+	switch cellKind {
+	case KindLin:
+		ndim = 1
+	case KindQua:
+		ndim = 2
+	case KindHex:
+		ndim = 3
+	default:
+		return 0, chk.Err("adaptive integration is not available for cellKind = %d\n", cellKind)
+	}
+	lo := QuadPointsGaussTensor("legendre", utl.IntVals(ndim, 3))
+	hi := QuadPointsGaussTensor("legendre", utl.IntVals(ndim, 4))
+	x := make([]float64, ndim)
+	integ := func(pts [][]float64, xc []float64, h float64) (sum float64) {
+		for _, p := range pts {
+			for i := 0; i < ndim; i++ {
+				x[i] = xc[i] + h*p[i]
+			}
+			sum += f(x) * p[3]
+		}
+		return sum * math.Pow(h, float64(ndim))
+	}
+	converged := true
+	var subdivide func(xc []float64, h float64, level int) float64
+	subdivide = func(xc []float64, h float64, level int) float64 {
+		resLo := integ(lo, xc, h)
+		resHi := integ(hi, xc, h)
+		if math.Abs(resHi-resLo) <= tol*math.Pow(h, float64(ndim)) {
+			return resHi
+		}
+		if level == maxSubdiv {
+			converged = false
+			return resHi
+		}
+		sum := 0.0
+		for m := 0; m < 1<<uint(ndim); m++ {
+			yc := make([]float64, ndim)
+			for i := 0; i < ndim; i++ {
+				yc[i] = xc[i] - h/2.0
+				if m&(1<<uint(i)) != 0 {
+					yc[i] = xc[i] + h/2.0
+				}
+			}
+			sum += subdivide(yc, h/2.0, level+1)
+		}
+		return sum
+	}
+	res = subdivide(make([]float64, ndim), 1, 0)
+	if !converged {
+		err = chk.Err("tolerance %g could not be met with %d levels of subdivision\n", tol, maxSubdiv)
+	}
+	return
+}
+
+// QuadPointsIntegratePAdaptive integrates a function over a reference cell by increasing the
+// polynomial degree of the quadrature rule until two consecutive results agree within tolerance
+//   Input:
+//     f        -- integrand function
+//     cellKind -- kind of reference cell; e.g. KindLin
+//     tol      -- absolute tolerance
+//     maxNpts  -- maximum number of points of the quadrature rules
+//   Output:
+//     res  -- the integral
+//     npts -- number of points of the last rule used
+//     err  -- an error is returned if tol could not be met with rules with up to maxNpts points;
+//             res is still the best available estimate in this case
+//   NOTE: the sequence of rules is given by IntPointsSelectSet with degree = 1, 2, 3, ...
+func QuadPointsIntegratePAdaptive(f fun.Sv, cellKind int, tol float64, maxNpts int) (res float64, npts int, err error) {
+	ndim := kindNdim(cellKind)
+	prevName := ""
+	for degree := 1; ; degree++ {
+		setName, e := IntPointsSelectSet(cellKind, degree)
+		if e != nil {
+			return res, npts, chk.Err("tolerance %g could not be met with the available rules for cellKind = %d\n", tol, cellKind)
+		}
+		if setName == prevName {
+			continue
+		}
+		P := IntPointsFindSet(cellKind, setName)
+		if len(P) > maxNpts {
+			return res, npts, chk.Err("tolerance %g could not be met with rules with up to %d points\n", tol, maxNpts)
+		}
+		val := QuadPointsIntegrate(P, ndim, f)
+		if prevName != "" && math.Abs(val-res) <= tol {
+			return val, len(P), nil
+		}
+		res, npts, prevName = val, len(P), setName
+	}
+}
+
+// QuadPointsToCoords splits quadrature points into coordinates and weights
+//   Input:
+//     pts  -- quadrature points [npts][4] where 4 means r,s,t,w
+//     ndim -- space dimension
+//   Output:
+//     X -- coordinates [npts][ndim]; i.e. without the unused (zero) coordinates
+//     W -- weights [npts]
+func QuadPointsToCoords(pts [][]float64, ndim int) (X [][]float64, W []float64) {
+	X = make([][]float64, len(pts))
+	W = make([]float64, len(pts))
+	for i, p := range pts {
+		X[i] = make([]float64, ndim)
+		copy(X[i], p[:ndim])
+		W[i] = p[3]
+	}
+	return
+}
+
+// QuadPointsWeights returns a copy of the weights of quadrature points [npts]
+func QuadPointsWeights(pts [][]float64) (W []float64) {
+	W = make([]float64, len(pts))
+	for i, p := range pts {
+		W[i] = p[3]
+	}
+	return
+}
+
+// QuadPointsFromCoords joins coordinates and weights into quadrature points
+//   Input:
+//     X -- coordinates [npts][ndim] with 1 ≤ ndim ≤ 3
+//     W -- weights [npts]
+//   Output:
+//     pts -- quadrature points [npts][4] where 4 means r,s,t,w
+func QuadPointsFromCoords(X [][]float64, W []float64) (pts [][]float64, err error) {
+	if len(X) != len(W) {
+		return nil, chk.Err("number of coordinates (%d) and weights (%d) must be equal\n", len(X), len(W))
+	}
+	pts = make([][]float64, len(X))
+	for i, x := range X {
+		if len(x) < 1 || len(x) > 3 || len(x) != len(X[0]) {
+			return nil, chk.Err("point %d has %d coordinates. all points must have the same number of coordinates in [1,3]\n", i, len(x))
+		}
+		pts[i] = make([]float64, 4)
+		copy(pts[i], x)
+		pts[i][3] = W[i]
+	}
+	return
+}
+
+// QuadPointsToBarycentric converts the reference coordinates of quadrature points of triangles or
+// tetrahedra to barycentric coordinates
+//   Input:
+//     pts      -- quadrature points [npts][4] where 4 means r,s,t,w
+//     cellKind -- KindTri or KindTet
+//   Output:
+//     L -- barycentric coordinates [npts][3] (λ0,λ1,λ2) of triangles or [npts][4] (λ0,λ1,λ2,λ3)
+//          of tetrahedra with Σλi=1
+//   NOTE: as in SimplexOrbit, the reference coordinates (r,s) or (r,s,t) are the first 2 or 3
+//         barycentric coordinates; i.e. the last one is 1-r-s or 1-r-s-t
+func QuadPointsToBarycentric(pts [][]float64, cellKind int) (L [][]float64) {
+	if cellKind != KindTri && cellKind != KindTet {
+		chk.Panic("barycentric coordinates are only available for triangles or tetrahedra. cellKind = %d is invalid\n", cellKind)
+	}
+	ndim := kindNdim(cellKind)
+	L = make([][]float64, len(pts))
+	for i, p := range pts {
+		L[i] = make([]float64, ndim+1)
+		L[i][ndim] = 1
+		for j := 0; j < ndim; j++ {
+			L[i][j] = p[j]
+			L[i][ndim] -= p[j]
+		}
+	}
+	return
+}
+
+// QuadPointsTransform maps quadrature points from the reference cell to a physical cell
+//   Input:
+//     pts   -- quadrature points [npts][4] where 4 means r,s,t,w
+//     ndim  -- space dimension = number of coordinates passed to mapFn
+//     mapFn -- mapping function returning the physical coordinates (len(phys) ≤ 3) and
+//              the determinant of the Jacobian of the mapping at the reference point ref
+//   Output:
+//     res -- new points [npts][4] where 4 means x,y,z,w⋅detJ
+func QuadPointsTransform(pts [][]float64, ndim int, mapFn func(ref []float64) (phys []float64, detJ float64)) (res [][]float64) {
+	res = make([][]float64, len(pts))
+	for i, p := range pts {
+		phys, detJ := mapFn(p[:ndim])
+		if len(phys) > 3 {
+			chk.Panic("mapping function must return at most 3 physical coordinates. %d is invalid\n", len(phys))
+		}
+		res[i] = make([]float64, 4)
+		copy(res[i], phys)
+		res[i][3] = p[3] * detJ
+	}
+	return
+}
+
+// QuadPointsToUnitInterval maps quadrature points from [-1,1]^ndim to [0,1]^ndim; i.e. x' = (1+x)/2
+// along each direction and the weights are multiplied by the Jacobian (1/2)^ndim
+//   NOTE: this is useful to interoperate with codes using the unit reference interval, square or
+//         cube. Only points of lin, qua and hex cells (or tensor-product rules) should be mapped
+func QuadPointsToUnitInterval(pts [][]float64, ndim int) (res [][]float64) {
+	detJ := math.Pow(0.5, float64(ndim))
+	return QuadPointsTransform(pts, ndim, func(ref []float64) (phys []float64, J float64) {
+		phys = make([]float64, ndim)
+		for i, x := range ref {
+			phys[i] = (1.0 + x) / 2.0
+		}
+		return phys, detJ
+	})
+}
+
+// QuadPointsScaleWeights returns a copy of the quadrature points with all weights multiplied by factor
+func QuadPointsScaleWeights(pts [][]float64, factor float64) (res [][]float64) {
+	res = utl.Clone(pts)
+	for _, p := range res {
+		p[3] *= factor
+	}
+	return
+}
+
+// QuadPointsSortByWeight returns a copy of the quadrature points sorted by weight; e.g. to
+// evaluate the points with the largest weights first
+//   descending -- sort in descending order of weights; otherwise ascending
+//   NOTE: the sorting is stable; however, the natural ordering of points (e.g. of tensor
+//         products) is lost
+func QuadPointsSortByWeight(pts [][]float64, descending bool) (res [][]float64) {
+	res = utl.Clone(pts)
+	sort.SliceStable(res, func(i, j int) bool {
+		if descending {
+			return res[i][3] > res[j][3]
+		}
+		return res[i][3] < res[j][3]
+	})
+	return
+}
+
+// QuadPointsCollapseDuplicates returns a copy of the quadrature points where points with
+// coincident coordinates are merged into a single point with the sum of weights
+//   tol -- tolerance to compare coordinates
+//   NOTE: the order of the first occurrences of points is kept
+func QuadPointsCollapseDuplicates(pts [][]float64, tol float64) (res [][]float64) {
+	res = make([][]float64, 0, len(pts))
+	for _, p := range pts {
+		found := false
+		for _, q := range res {
+			if math.Abs(p[0]-q[0]) <= tol && math.Abs(p[1]-q[1]) <= tol && math.Abs(p[2]-q[2]) <= tol {
+				q[3] += p[3]
+				found = true
+				break
+			}
+		}
+		if !found {
+			res = append(res, []float64{p[0], p[1], p[2], p[3]})
+		}
+	}
+	return
+}
+
+// QuadPointsForSimplex maps quadrature points from the reference triangle (tetrahedron) to a
+// physical triangle (tetrahedron). The Jacobian of the mapping is constant; thus the weights are
+// multiplied by 2⋅area (6⋅volume)
+//   Input:
+//     pts      -- quadrature points [npts][4] where 4 means r,s,t,w
+//     vertices -- coordinates of vertices [ndim+1][ndim] with ndim = 2 (triangle) or 3 (tetrahedron)
+//   Output:
+//     res -- new points [npts][4] where 4 means x,y,z,w⋅|detJ|
+func QuadPointsForSimplex(pts [][]float64, vertices [][]float64) (res [][]float64) {
+	ndim := len(vertices) - 1
+	if ndim < 2 || ndim > 3 {
+		chk.Panic("number of vertices of simplex must be 3 (triangle) or 4 (tetrahedron). %d is invalid\n", len(vertices))
+	}
+	J := utl.Alloc(ndim, ndim) // J[i][j] = dx_i/dr_j
+	for j := 0; j < ndim; j++ {
+		if len(vertices[j+1]) != ndim || len(vertices[0]) != ndim {
+			chk.Panic("vertices of simplex must have %d coordinates\n", ndim)
+		}
+		for i := 0; i < ndim; i++ {
+			J[i][j] = vertices[j+1][i] - vertices[0][i]
+		}
+	}
+	var detJ float64
+	if ndim == 2 {
+		detJ = J[0][0]*J[1][1] - J[0][1]*J[1][0]
+	} else {
+		detJ = J[0][0]*(J[1][1]*J[2][2]-J[1][2]*J[2][1]) -
+			J[0][1]*(J[1][0]*J[2][2]-J[1][2]*J[2][0]) +
+			J[0][2]*(J[1][0]*J[2][1]-J[1][1]*J[2][0])
+	}
+	detJ = math.Abs(detJ)
+	return QuadPointsTransform(pts, ndim, func(ref []float64) (phys []float64, d float64) {
+		phys = make([]float64, ndim)
+		for i := 0; i < ndim; i++ {
+			phys[i] = vertices[0][i]
+			for j := 0; j < ndim; j++ {
+				phys[i] += J[i][j] * ref[j]
+			}
+		}
+		return phys, detJ
+	})
+}
+
+// QuadPointsSumWeights returns the sum of weights of quadrature points
+//   NOTE: for a valid set of points, the sum equals the measure of the reference cell;
+//         e.g. 2 for lin, 4 for qua, 8 for hex, 1/2 for tri, 1/6 for tet, 1 for wed and 4/3 for pyr
+func QuadPointsSumWeights(pts [][]float64) (sum float64) {
+	for _, p := range pts {
+		sum += p[3]
+	}
+	return
+}
+
+// QuadPointsExactDegree finds the highest total degree of monomials integrated exactly by a set of
+// quadrature points over the reference cell; i.e. the degree is measured by brute force
+//   degreeMax -- maximum degree to be checked
+//   NOTE: (1) a monomial is considered integrated exactly if the relative error is smaller
+//             than 1e-12
+//         (2) a negative value means that not even constant functions are integrated exactly
+func QuadPointsExactDegree(pts [][]float64, cellKind, degreeMax int) (degree int) {
+	for deg := 0; deg <= degreeMax; deg++ {
+		if QuadPointsCheckExactness(pts, cellKind, deg, 1e-12) != nil {
+			return deg - 1
+		}
+	}
+	return degreeMax
+}
+
+// QuadPointsCheckExactness checks whether a set of quadrature points integrates exactly all
+// monomials r^a⋅s^b⋅t^c with a+b+c ≤ degree over the reference cell
+//   tol -- tolerance for the relative error; e.g. 1e-12
+//   NOTE: the monomials are checked in increasing order of total degree and an error naming the
+//         first monomial which is not integrated exactly is returned
+func QuadPointsCheckExactness(pts [][]float64, cellKind, degree int, tol float64) (err error) {
+	ndim := kindNdim(cellKind)
+	for deg := 0; deg <= degree; deg++ {
+		for a := 0; a <= deg; a++ {
+			for b := 0; a+b <= deg; b++ {
+				c := deg - a - b
+				if (ndim < 2 && b > 0) || (ndim < 3 && c > 0) {
+					continue
+				}
+				ana := monomialIntegral(cellKind, a, b, c)
+				res, scale := 0.0, 0.0
+				for _, p := range pts {
+					v := math.Pow(p[0], float64(a)) * math.Pow(p[1], float64(b)) * math.Pow(p[2], float64(c)) * p[3]
+					res += v
+					scale += math.Abs(v)
+				}
+				if math.Abs(res-ana) > tol*math.Max(scale, math.Abs(ana)) {
+					return chk.Err("monomial r^%d⋅s^%d⋅t^%d is not integrated exactly: %g != %g\n", a, b, c, res, ana)
+				}
+			}
+		}
+	}
+	return
+}
+
+// monomialIntegral computes the integral of x^a⋅y^b⋅z^c over the reference cell
+func monomialIntegral(cellKind, a, b, c int) (res float64) {
+	fact := func(n int) (res float64) {
+		res = 1
+		for i := 2; i <= n; i++ {
+			res *= float64(i)
+		}
+		return
+	}
+	switch cellKind {
+	case KindTri:
+		return fact(a) * fact(b) / fact(a+b+2)
+	case KindTet:
+		return fact(a) * fact(b) * fact(c) / fact(a+b+c+3)
+	case KindWed:
+		if c%2 == 1 {
+			return 0
+		}
+		return fact(a) * fact(b) / fact(a+b+2) * 2.0 / float64(c+1)
+	case KindPyr:
+		if a%2 == 1 || b%2 == 1 {
+			return 0
+		}
+		m := a + b + 2
+		return 4.0 / float64((a+1)*(b+1)) * fact(c) * fact(m) / fact(c+m+1)
+	}
+	res = 1
+	for _, k := range []int{a, b, c}[:kindNdim(cellKind)] {
+		if k%2 == 1 {
+			return 0
+		}
+		res *= 2.0 / float64(k+1)
+	}
+	return
+}
+
+// QuadPointsBounds returns the limits of the bounding box of quadrature points
+//   xmin -- minimum coordinates [ndim]
+//   xmax -- maximum coordinates [ndim]
+func QuadPointsBounds(pts [][]float64, ndim int) (xmin, xmax []float64) {
+	xmin = make([]float64, ndim)
+	xmax = make([]float64, ndim)
+	for i, p := range pts {
+		for j := 0; j < ndim; j++ {
+			if i == 0 || p[j] < xmin[j] {
+				xmin[j] = p[j]
+			}
+			if i == 0 || p[j] > xmax[j] {
+				xmax[j] = p[j]
+			}
+		}
+	}
+	return
+}
+
+// QuadPointsCentroid returns the weighted centroid of quadrature points; i.e. Σ(wi⋅xi) / Σwi
+//   NOTE: for a set of points integrating linear functions exactly, the result is the centroid
+//         of the reference cell
+func QuadPointsCentroid(pts [][]float64, ndim int) (xc []float64) {
+	xc = make([]float64, ndim)
+	sum := 0.0
+	for _, p := range pts {
+		for j := 0; j < ndim; j++ {
+			xc[j] += p[3] * p[j]
+		}
+		sum += p[3]
+	}
+	for j := 0; j < ndim; j++ {
+		xc[j] /= sum
+	}
+	return
+}
+
+// QuadPointsValidate checks the points and weights of a set of integration points for a cell kind
+//   tol -- tolerance for the position of points and for the sum of weights
+//   NOTE: the following conditions are checked in this order: (1) all points have 4 values;
+//         (2) coordinates and weights are finite numbers; (3) unused coordinates are zero;
+//         (4) points are within the reference cell; and (5) the sum of weights is equal to
+//         the measure of the reference cell. An error is returned for the first failure
+func QuadPointsValidate(pts [][]float64, cellKind int, tol float64) (err error) {
+	if cellKind < 0 || cellKind >= KindNumMax {
+		return chk.Err("cellKind = %d is invalid\n", cellKind)
+	}
+	ndim := kindNdim(cellKind)
+	for i, p := range pts {
+		if len(p) != 4 {
+			return chk.Err("point %d must have 4 values (r,s,t,w). %d is invalid\n", i, len(p))
+		}
+		for j, v := range p {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return chk.Err("value %d of point %d is not a finite number: %v\n", j, i, v)
+			}
+		}
+		for j := ndim; j < 3; j++ {
+			if p[j] != 0 {
+				return chk.Err("coordinate %d of point %d must be zero because ndim=%d\n", j, i, ndim)
+			}
+		}
+		if !InReferenceCell(cellKind, p, tol) {
+			return chk.Err("point %d = %v is outside the reference cell of cellKind = %d\n", i, p[:ndim], cellKind)
+		}
+	}
+	measure, err := ReferenceMeasure(cellKind)
+	if err != nil {
+		return
+	}
+	return QuadPointsCheckWeights(pts, measure, tol)
+}
+
+// QuadPointsMatch returns whether two sets of quadrature points are equal or not, regardless of
+// the order of points; i.e. the sets are compared as sets and not as sequences
+//   tol -- tolerance for the coordinates and weights
+func QuadPointsMatch(a, b [][]float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	used := make([]bool, len(b))
+	for _, p := range a {
+		found := false
+		for j, q := range b {
+			if used[j] {
+				continue
+			}
+			if math.Abs(p[0]-q[0]) <= tol && math.Abs(p[1]-q[1]) <= tol && math.Abs(p[2]-q[2]) <= tol && math.Abs(p[3]-q[3]) <= tol {
+				used[j] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// QuadPointsCheckSymmetry returns whether a set of quadrature points is invariant under the
+// symmetries of the reference cell; i.e. whether the set is mapped onto itself by reflections and
+// rotations of the reference cell. The sets are compared with QuadPointsMatch
+//   tol -- tolerance for the coordinates and weights
+//   NOTE: the checked symmetries are:
+//           lin, qua, hex -- reflections x_i → -x_i and swaps x_i ↔ x_j
+//           tri, tet      -- permutations of the barycentric coordinates
+//           wed           -- permutations of the barycentric coordinates of the triangle and z → -z
+//           pyr           -- reflections and swap of x and y
+func QuadPointsCheckSymmetry(pts [][]float64, cellKind int, tol float64) bool {
+	reflect := func(i int) func(x []float64) { return func(x []float64) { x[i] = -x[i] } }
+	swap := func(i, j int) func(x []float64) { return func(x []float64) { x[i], x[j] = x[j], x[i] } }
+	swapBary := func(n int) func(x []float64) { // swaps x_0 with the barycentric coordinate 1-Σx_i
+		return func(x []float64) {
+			sum := 0.0
+			for i := 0; i < n; i++ {
+				sum += x[i]
+			}
+			x[0] = 1.0 - sum
+		}
+	}
+	var ops []func(x []float64) // generators of the group of symmetries
+	switch cellKind {
+	case KindLin:
+		ops = append(ops, reflect(0))
+	case KindQua:
+		ops = append(ops, reflect(0), reflect(1), swap(0, 1))
+	case KindHex:
+		ops = append(ops, reflect(0), reflect(1), reflect(2), swap(0, 1), swap(1, 2))
+	case KindTri:
+		ops = append(ops, swap(0, 1), swapBary(2))
+	case KindTet:
+		ops = append(ops, swap(0, 1), swap(1, 2), swapBary(3))
+	case KindWed:
+		ops = append(ops, swap(0, 1), swapBary(2), reflect(2))
+	case KindPyr:
+		ops = append(ops, reflect(0), reflect(1), swap(0, 1))
+	default:
+		chk.Panic("cellKind = %d is invalid\n", cellKind)
+	}
+	mapped := make([][]float64, len(pts))
+	for _, op := range ops {
+		for i, p := range pts {
+			mapped[i] = []float64{p[0], p[1], p[2], p[3]}
+			op(mapped[i])
+		}
+		if !QuadPointsMatch(pts, mapped, tol) {
+			return false
+		}
+	}
+	return true
+}
+
+// InReferenceCell returns whether point x is inside the reference cell of cell kind or not
+//   x   -- coordinates of point; len(x) ≥ ndim of cell kind (other values are ignored)
+//   tol -- tolerance to consider points on the boundary
+func InReferenceCell(cellKind int, x []float64, tol float64) bool {
+	ndim := kindNdim(cellKind)
+	if len(x) < ndim {
+		chk.Panic("point must have at least %d coordinates. %d is invalid\n", ndim, len(x))
+	}
+	var r, s, t float64
+	r = x[0]
+	if ndim > 1 {
+		s = x[1]
+	}
+	if ndim > 2 {
+		t = x[2]
+	}
+	in := func(a float64) bool { return a >= -1-tol && a <= 1+tol } // in [-1,1]
+	switch cellKind {
+	case KindLin:
+		return in(r)
+	case KindQua:
+		return in(r) && in(s)
+	case KindHex:
+		return in(r) && in(s) && in(t)
+	case KindTri:
+		return r >= -tol && s >= -tol && r+s <= 1+tol
+	case KindTet:
+		return r >= -tol && s >= -tol && t >= -tol && r+s+t <= 1+tol
+	case KindWed:
+		return r >= -tol && s >= -tol && r+s <= 1+tol && in(t)
+	case KindPyr:
+		return t >= -tol && t <= 1+tol && math.Abs(r) <= 1-t+tol && math.Abs(s) <= 1-t+tol
+	}
+	chk.Panic("cellKind = %d is invalid\n", cellKind)
+	return false
+}
+
+// QuadPointsHasNegativeWeights returns whether at least one weight is negative or not
+//   NOTE: rules with negative weights may cause problems when integrating non-smooth functions
+//         or quantities that must remain positive (e.g. lumped mass matrices)
+func QuadPointsHasNegativeWeights(pts [][]float64) bool {
+	for _, p := range pts {
+		if p[3] < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// QuadPointsSplitByWeightSign splits quadrature points into the points with positive (or zero)
+// weights and the points with negative weights; e.g. to analyse the contributions of rules with
+// negative weights separately. The order of points is preserved and the points are copied
+func QuadPointsSplitByWeightSign(pts [][]float64) (positive, negative [][]float64) {
+	for _, p := range pts {
+		if p[3] < 0 {
+			negative = append(negative, utl.GetCopy(p))
+		} else {
+			positive = append(positive, utl.GetCopy(p))
+		}
+	}
+	return
+}
+
+// QuadPointsCheckWeights checks whether the sum of weights equals the expected value or not
+//   expected -- expected sum; e.g. the measure of the reference cell
+//   tol      -- tolerance for the absolute difference
+func QuadPointsCheckWeights(pts [][]float64, expected, tol float64) (err error) {
+	sum := QuadPointsSumWeights(pts)
+	if math.IsNaN(sum) || math.Abs(sum-expected) > tol {
+		return chk.Err("sum of weights = %v is different than expected = %v (tol = %g)\n", sum, expected, tol)
+	}
+	return
+}
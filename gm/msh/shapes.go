@@ -13,7 +13,9 @@ const (
 	KindQua    = 2 // "qua" cell kind
 	KindTet    = 3 // "tet" cell kind
 	KindHex    = 4 // "hex" cell kind
-	KindNumMax = 5 // max number of kinds
+	KindWed    = 5 // "wed" cell kind (wedge or triangular prism)
+	KindPyr    = 6 // "pyr" cell kind (pyramid)
+	KindNumMax = 7 // max number of kinds
 )
 
 // cell types
@@ -0,0 +1,30 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package msh
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/num"
+)
+
+var benchmarkPts [][]float64
+
+func BenchmarkGaussLegendreNoCache(b *testing.B) {
+	var pts [][]float64
+	for i := 0; i < b.N; i++ {
+		x, w := num.GaussLegendreXW(-1, 1, 5)
+		pts = quadPointsTensor([][]float64{x}, [][]float64{w})
+	}
+	benchmarkPts = pts
+}
+
+func BenchmarkGaussLegendreCached(b *testing.B) {
+	var pts [][]float64
+	for i := 0; i < b.N; i++ {
+		pts = QuadPointsGaussLegendre(1, 5)
+	}
+	benchmarkPts = pts
+}
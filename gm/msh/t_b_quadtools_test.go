@@ -0,0 +1,67 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package msh
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/cpmech/gosl/la"
+)
+
+var benchmarkRes float64
+
+// benchmarkSlowFcn mimics an expensive integrand; e.g. a material model
+func benchmarkSlowFcn(x la.Vector) float64 {
+	time.Sleep(10 * time.Microsecond)
+	return math.Exp(x[0] + x[1] + x[2])
+}
+
+func BenchmarkIntegrateSerial(b *testing.B) {
+	pts := QuadPointsGaussLegendre(3, 64)
+	var res float64
+	for i := 0; i < b.N; i++ {
+		res = QuadPointsIntegrate(pts, 3, benchmarkSlowFcn)
+	}
+	benchmarkRes = res
+}
+
+func BenchmarkIntegratePar(b *testing.B) {
+	pts := QuadPointsGaussLegendre(3, 64)
+	var res float64
+	for i := 0; i < b.N; i++ {
+		res = QuadPointsIntegratePar(pts, 3, benchmarkSlowFcn, 8)
+	}
+	benchmarkRes = res
+}
+
+func BenchmarkIntegrateClosure(b *testing.B) {
+	o := NewIntPointsSet(KindHex, "legendre_27")
+	f := func(x la.Vector) float64 { return x[0]*x[0] + x[1]*x[2] }
+	b.ReportAllocs()
+	b.ResetTimer()
+	var res float64
+	for i := 0; i < b.N; i++ {
+		res = QuadPointsIntegrate(o.P, o.Ndim, f)
+	}
+	benchmarkRes = res
+}
+
+func BenchmarkIntegrateValues(b *testing.B) {
+	o := NewIntPointsSet(KindHex, "legendre_27")
+	X, _ := o.Coords()
+	values := make([]float64, o.Npts)
+	for i, x := range X {
+		values[i] = x[0]*x[0] + x[1]*x[2]
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	var res float64
+	for i := 0; i < b.N; i++ {
+		res = o.IntegrateValues(values)
+	}
+	benchmarkRes = res
+}
@@ -0,0 +1,97 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package msh
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestQuadcache01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadcache01. least-recently-used cache")
+
+	c := newQuadCache(3)
+	for i := 0; i < 5; i++ {
+		c.put(i, i*10)
+	}
+	chk.Int(tst, "len", c.order.Len(), 3)
+	for _, i := range []int{0, 1} {
+		if _, ok := c.get(i); ok {
+			tst.Errorf("entry %d should have been evicted\n", i)
+		}
+	}
+	for _, i := range []int{2, 3, 4} {
+		v, ok := c.get(i)
+		if !ok {
+			tst.Errorf("entry %d should be in the cache\n", i)
+			return
+		}
+		chk.Int(tst, io.Sf("value %d", i), v.(int), i*10)
+	}
+	chk.Int(tst, "hits", c.hits, 3)
+	chk.Int(tst, "misses", c.misses, 2)
+
+	// 2 is now the least recently used; thus, using 2 makes 3 the least recently used
+	c.get(2)
+	c.put(5, 50)
+	if _, ok := c.items[3]; ok {
+		tst.Errorf("entry 3 should have been evicted\n")
+	}
+	if _, ok := c.items[2]; !ok {
+		tst.Errorf("entry 2 should be in the cache\n")
+	}
+
+	// smaller limit
+	c.setLimit(1)
+	chk.Int(tst, "len", c.order.Len(), 1)
+	if _, ok := c.items[5]; !ok {
+		tst.Errorf("entry 5 should be in the cache\n")
+	}
+}
+
+func TestQuadcache02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadcache02. limit and stats of caches of generated rules")
+
+	SetCacheLimit(2)
+	defer SetCacheLimit(DefaultCacheLimit)
+
+	// 1D positions and weights
+	for n := 41; n <= 44; n++ {
+		quadPoints1d("lobatto", n)
+	}
+	chk.Int(tst, "abscissae", NumCachedAbscissae(), 2)
+	for n, cached := range map[int]bool{41: false, 42: false, 43: true, 44: true} {
+		if _, ok := quadPoints1dCache.items[quadPoints1dKey{"lobatto", n}]; ok != cached {
+			tst.Errorf("lobatto %d: cached should be %v\n", n, cached)
+		}
+	}
+
+	// generated sets
+	_, hits0, misses0 := CacheStats()
+	for _, name := range []string{"legendre_41", "legendre_42", "legendre_41", "legendre_43"} {
+		IntPointsFindSet(KindLin, name)
+	}
+	entries, hits, misses := CacheStats()
+	chk.Int(tst, "entries", entries, 4)
+	chk.Int(tst, "hits", hits-hits0, 1)         // legendre_41 again
+	chk.Int(tst, "misses", misses-misses0, 3+3) // three sets + their 1D positions and weights
+	for name, cached := range map[string]bool{"legendre_41": true, "legendre_42": false, "legendre_43": true} {
+		if _, ok := intPointsGenerated.items[intPointsGeneratedKey{KindLin, name}]; ok != cached {
+			tst.Errorf("%s: cached should be %v\n", name, cached)
+		}
+	}
+
+	// errors
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		SetCacheLimit(0)
+	}()
+}
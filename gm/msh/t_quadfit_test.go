@@ -0,0 +1,68 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package msh
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestQuadfit01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadfit01. fit rule to moments")
+
+	// moments of monomials r^a s^b (a,b ≤ 3) on the reference square
+	moments := make(map[[2]int]float64)
+	for a := 0; a <= 3; a++ {
+		for b := 0; b <= 3; b++ {
+			moments[[2]int{a, b}] = monomialIntegral(KindQua, a, b, 0)
+		}
+	}
+
+	// recover qua legendre_4
+	o, err := NewIntPointsSetFromMoments("qua4", 4, moments)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("%v\n", o)
+	ref := NewIntPointsSet(KindQua, "legendre_4")
+	if !QuadPointsMatch(o.P, ref.P, 1e-10) {
+		tst.Errorf("fitted points do not match legendre_4\n")
+		return
+	}
+	chk.Int(tst, "degree", QuadPointsExactDegree(o.P, KindQua, 10), 3)
+
+	// recover lin legendre_2
+	o, err = NewIntPointsSetFromMoments("lin2", 2, map[[2]int]float64{{0, 0}: 2, {1, 0}: 0, {2, 0}: 2.0 / 3.0, {3, 0}: 0})
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "|x0|", 1e-12, math.Abs(o.P[0][0]), 1/math.Sqrt(3))
+	chk.Float64(tst, "w0", 1e-12, o.P[0][3], 1)
+
+	// errors
+	_, err = NewIntPointsSetFromMoments("hex8", 8, moments)
+	if err == nil {
+		tst.Errorf("ndim = 3 should have failed\n")
+	}
+	_, err = NewIntPointsSetFromMoments("qua4", 9, moments)
+	if err == nil {
+		tst.Errorf("too few moments should have failed\n")
+	}
+	_, err = NewIntPointsSetFromMoments("lin2", 1, map[[2]int]float64{{0, 0}: 2, {0, 1}: 0})
+	if err == nil {
+		tst.Errorf("exponent of s with lin should have failed\n")
+	}
+	_, err = NewIntPointsSetFromMoments("qua4", 1, map[[2]int]float64{{0, 0}: 4, {1, 0}: 0, {0, 1}: 0, {2, 0}: 4.0 / 3.0})
+	if err == nil {
+		tst.Errorf("inconsistent moments should have failed\n")
+	}
+}
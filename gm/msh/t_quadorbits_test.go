@@ -0,0 +1,159 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package msh
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestQuadorbits01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadorbits01. triangle rules from orbits")
+
+	// 12-point rule
+	pts := QuadPointsFromOrbits(KindTri, []SimplexOrbit{
+		{"s21", []float64{0.063089014491502}, 0.0254224531851035},
+		{"s21", []float64{0.249286745170910}, 0.0583931378631895},
+		{"s111", []float64{0.053145049844817, 0.310352451033784}, 0.041425537809187},
+	})
+	io.Pforan("pts = %v\n", pts)
+	chk.Int(tst, "npts", len(pts), 12)
+	if !QuadPointsMatch(pts, GetIntPoints()[KindTri]["internal_12"], 1e-15) {
+		tst.Errorf("orbits do not reproduce internal_12\n")
+	}
+
+	// 7-point rule
+	SQ15 := math.Sqrt(15.0)
+	pts = QuadPointsFromOrbits(KindTri, []SimplexOrbit{
+		{"s3", nil, 9.0 / 80.0},
+		{"s21", []float64{(6.0 - SQ15) / 21.0}, (155.0 - SQ15) / 2400.0},
+		{"s21", []float64{(6.0 + SQ15) / 21.0}, (155.0 + SQ15) / 2400.0},
+	})
+	if !QuadPointsMatch(pts, GetIntPoints()[KindTri]["internal_7"], 1e-15) {
+		tst.Errorf("orbits do not reproduce internal_7\n")
+	}
+
+	// points of an orbit are all different
+	pts = QuadPointsFromOrbits(KindTri, []SimplexOrbit{{"s111", []float64{0.1, 0.2}, 1.0 / 12.0}})
+	chk.Deep2(tst, "s111", 1e-15, pts, [][]float64{
+		{0.1, 0.2, 0, 1.0 / 12.0},
+		{0.1, 0.7, 0, 1.0 / 12.0},
+		{0.2, 0.1, 0, 1.0 / 12.0},
+		{0.2, 0.7, 0, 1.0 / 12.0},
+		{0.7, 0.1, 0, 1.0 / 12.0},
+		{0.7, 0.2, 0, 1.0 / 12.0},
+	})
+
+	// invalid orbits
+	for _, orbits := range [][]SimplexOrbit{
+		{{"s31", []float64{0.1}, 1}},
+		{{"s21", []float64{0.1, 0.2}, 1}},
+		{{"s5", nil, 1}},
+	} {
+		func() {
+			defer chk.RecoverTstPanicIsOK(tst)
+			QuadPointsFromOrbits(KindTri, orbits)
+		}()
+	}
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		QuadPointsFromOrbits(KindQua, nil)
+	}()
+}
+
+func TestQuadorbits02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadorbits02. tetrahedron rules from orbits")
+
+	// Keast's 15-point rule
+	SQ15 := math.Sqrt(15.0)
+	pts := QuadPointsFromOrbits(KindTet, []SimplexOrbit{
+		{"s4", nil, 8.0 / 405.0},
+		{"s31", []float64{(7.0 - SQ15) / 34.0}, (2665.0 + 14.0*SQ15) / 226800.0},
+		{"s31", []float64{(7.0 + SQ15) / 34.0}, (2665.0 - 14.0*SQ15) / 226800.0},
+		{"s22", []float64{(5.0 - SQ15) / 20.0}, 5.0 / 567.0},
+	})
+	chk.Int(tst, "npts", len(pts), 15)
+	if !QuadPointsMatch(pts, GetIntPoints()[KindTet]["internal_15"], 1e-15) {
+		tst.Errorf("orbits do not reproduce internal_15\n")
+	}
+
+	// number of points of each orbit
+	for typ, n := range map[string]int{"s4": 1, "s31": 4, "s22": 6, "s211": 12, "s1111": 24} {
+		prms := map[string][]float64{"s31": {0.1}, "s22": {0.1}, "s211": {0.1, 0.3}, "s1111": {0.1, 0.2, 0.3}}[typ]
+		pts = QuadPointsFromOrbits(KindTet, []SimplexOrbit{{typ, prms, 1}})
+		chk.Int(tst, typ, len(pts), n)
+	}
+}
+
+func TestQuadorbits03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadorbits03. expansion of orbits")
+
+	for _, c := range []struct {
+		λ    []float64
+		npts int
+	}{
+		{[]float64{1.0 / 3.0, 1.0 / 3.0, 1.0 / 3.0}, 1},
+		{[]float64{0.1, 0.1, 0.8}, 3},
+		{[]float64{0.1, 0.2, 0.7}, 6},
+		{[]float64{0.25, 0.25, 0.25, 0.25}, 1},
+		{[]float64{0.1, 0.1, 0.1, 0.7}, 4},
+		{[]float64{0.1, 0.1, 0.4, 0.4}, 6},
+		{[]float64{0.1, 0.1, 0.2, 0.6}, 12},
+		{[]float64{0.1, 0.2, 0.3, 0.4}, 24},
+	} {
+		res := ExpandSimplexOrbit(c.λ)
+		io.Pforan("λ = %v: npts = %d\n", c.λ, len(res))
+		chk.Int(tst, "npts", len(res), c.npts)
+		cellKind := KindTri
+		if len(c.λ) == 4 {
+			cellKind = KindTet
+		}
+		for i, λ := range res {
+			chk.Int(tst, "len(λ)", len(λ), len(c.λ))
+			sum := 0.0
+			for _, v := range λ {
+				sum += v
+			}
+			chk.Float64(tst, "Σλ", 1e-15, sum, 1)
+			x := make([]float64, 3)
+			copy(x, λ[:len(λ)-1])
+			if !InReferenceCell(cellKind, x, 1e-15) {
+				tst.Errorf("point %v is outside reference cell\n", λ)
+				return
+			}
+			for j := 0; j < i; j++ {
+				same := true
+				for k := range λ {
+					if res[j][k] != λ[k] {
+						same = false
+					}
+				}
+				if same {
+					tst.Errorf("points %d and %d are repeated\n", j, i)
+					return
+				}
+			}
+		}
+	}
+
+	// invalid input
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		ExpandSimplexOrbit([]float64{0.5, 0.5})
+	}()
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		ExpandSimplexOrbit([]float64{0.1, 0.2, 0.3})
+	}()
+}
@@ -0,0 +1,1480 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package msh
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/fun/dbf"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/plt"
+	"github.com/cpmech/gosl/utl"
+)
+
+func TestQuadset01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset01. json")
+
+	// marshal
+	o := NewIntPointsSet(KindQua, "wilson8default_8")
+	b, err := json.Marshal(o)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("%s\n", b)
+
+	// unmarshal
+	var p IntPointsSet
+	err = json.Unmarshal(b, &p)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Int(tst, "kind", p.Kind, KindQua)
+	chk.String(tst, p.Name, "wilson8default_8")
+	chk.Int(tst, "ndim", p.Ndim, 2)
+	chk.Int(tst, "npts", p.Npts, 8)
+	chk.Deep2(tst, "P", 1e-17, p.P, o.P)
+
+	// inconsistent data
+	for _, str := range []string{
+		`{"kind":2,"name":"a","ndim":0,"npts":1,"p":[[0,0,0,4]]}`,
+		`{"kind":2,"name":"a","ndim":2,"npts":2,"p":[[0,0,0,4]]}`,
+		`{"kind":2,"name":"a","ndim":2,"npts":1,"p":[[0,0,4]]}`,
+		`{"kind":1,"name":"a","ndim":1,"npts":1,"p":[[0,1,0,2]]}`,
+	} {
+		err = json.Unmarshal([]byte(str), &p)
+		if err == nil {
+			tst.Errorf("unmarshal should have failed with %s\n", str)
+			return
+		}
+		io.Pf("OK, caught the following error: %v", err)
+	}
+}
+
+func TestQuadset02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset02. string")
+
+	o := NewIntPointsSet(KindLin, "legendre_2")
+	l := o.String()
+	io.Pf("%v", l)
+	chk.String(tst, l, `kind = 0, name = "legendre_2", ndim = 1, npts = 2
+   0:  -5.773502691896257e-01 |   1.000000000000000e+00
+   1:   5.773502691896257e-01 |   1.000000000000000e+00
+`)
+}
+
+func TestQuadset03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset03. clone")
+
+	o := NewIntPointsSet(KindQua, "legendre_4")
+	p := o.Clone()
+	for _, x := range p.P {
+		x[0] = 2*x[0] + 1
+		x[3] *= 2
+	}
+	chk.Float64(tst, "p: sum(w)", 1e-15, QuadPointsSumWeights(p.P), 8)
+	chk.Float64(tst, "o: sum(w)", 1e-15, QuadPointsSumWeights(o.P), 4)
+	chk.Deep2(tst, "database", 1e-15, GetIntPoints()[KindQua]["legendre_4"], QuadPointsGaussLegendre(2, 4))
+	chk.String(tst, p.Name, o.Name)
+	chk.Int(tst, "npts", p.Npts, o.Npts)
+}
+
+func TestQuadset04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset04. transform")
+
+	// map reference square to [1,4] × [2,3]
+	o := NewIntPointsSet(KindQua, "legendre_9")
+	p := o.Transform(func(ref []float64) (phys []float64, detJ float64) {
+		phys = []float64{2.5 + 1.5*ref[0], 2.5 + 0.5*ref[1]}
+		detJ = 1.5 * 0.5
+		return
+	})
+	chk.String(tst, p.Name, "legendre_9-mapped")
+	chk.Int(tst, "npts", p.Npts, 9)
+	chk.Float64(tst, "area", 1e-15, QuadPointsSumWeights(p.P), 3)
+	chk.Array(tst, "p0", 1e-15, p.P[0], []float64{2.5 + 1.5*o.P[0][0], 2.5 + 0.5*o.P[0][1], 0, 0.75 * o.P[0][3]})
+	chk.Float64(tst, "o: sum(w)", 1e-15, QuadPointsSumWeights(o.P), 4)
+
+	// ∫x⋅y over [1,4] × [2,3] = (16-1)/2 ⋅ (9-4)/2
+	res := 0.0
+	for _, q := range p.P {
+		res += q[0] * q[1] * q[3]
+	}
+	chk.Float64(tst, "∫x⋅y", 1e-14, res, 75.0/4.0)
+
+	// invalid mapping
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		o.Transform(func(ref []float64) ([]float64, float64) { return make([]float64, 4), 1 })
+	}()
+}
+
+func TestQuadset05(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset05. save and load")
+
+	// save
+	o := NewIntPointsSet(KindQua, "wilson8default_8")
+	o.Save("/tmp/gosl/gm/quadset05.gob")
+
+	// load
+	var p IntPointsSet
+	p.Load("/tmp/gosl/gm/quadset05.gob")
+	chk.Int(tst, "kind", p.Kind, KindQua)
+	chk.String(tst, p.Name, "wilson8default_8")
+	chk.Int(tst, "ndim", p.Ndim, 2)
+	chk.Int(tst, "npts", p.Npts, 8)
+	chk.Deep2(tst, "P", 1e-17, p.P, o.P)
+
+	// find the set in the database using the loaded data
+	chk.Deep2(tst, "find", 1e-17, IntPointsFindSet(p.Kind, p.Name), p.P)
+	chk.Int(tst, "degree", p.Degree(), 5)
+
+	// inconsistent data
+	q := o.Clone()
+	q.Npts = 3
+	q.Save("/tmp/gosl/gm/quadset05-wrong.gob")
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		p.Load("/tmp/gosl/gm/quadset05-wrong.gob")
+	}()
+}
+
+func TestQuadset06(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset06. register")
+
+	// custom 7-point rule
+	o := NewIntPointsSet(KindTri, "internal_7").Clone()
+	o.Name = "custom_7"
+	defer delete(GetIntPoints()[KindTri], o.Name)
+	err := IntPointsRegister(o, false)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+
+	// retrieve
+	p := NewIntPointsSet(KindTri, "custom_7")
+	chk.Int(tst, "npts", p.Npts, 7)
+	chk.Deep2(tst, "P", 1e-17, p.P, GetIntPoints()[KindTri]["internal_7"])
+
+	// registered set is a copy
+	o.P[0][3] = 123
+	chk.Float64(tst, "w0", 1e-17, IntPointsFindSet(KindTri, "custom_7")[0][3], 9.0/80.0)
+
+	// duplicates
+	err = IntPointsRegister(o, false)
+	if err == nil {
+		tst.Errorf("registering a duplicate should have failed\n")
+		return
+	}
+	io.Pf("OK, caught the following error: %v", err)
+	err = IntPointsRegister(o, true)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "w0", 1e-17, IntPointsFindSet(KindTri, "custom_7")[0][3], 123)
+
+	// inconsistent sets
+	for _, q := range []*IntPointsSet{
+		{Kind: KindNumMax, Name: "a", Ndim: 2, Npts: 1, P: [][]float64{{0, 0, 0, 1}}},
+		{Kind: KindTri, Name: "", Ndim: 2, Npts: 1, P: [][]float64{{0, 0, 0, 1}}},
+		{Kind: KindTri, Name: "a", Ndim: 3, Npts: 1, P: [][]float64{{0, 0, 0, 1}}},
+		{Kind: KindTri, Name: "a", Ndim: 2, Npts: 2, P: [][]float64{{0, 0, 0, 1}}},
+	} {
+		err = IntPointsRegister(q, false)
+		if err == nil {
+			tst.Errorf("registering an inconsistent set should have failed\n")
+			return
+		}
+		io.Pf("OK, caught the following error: %v", err)
+	}
+}
+
+func TestQuadset07(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset07. coordinates and weights")
+
+	// split
+	o := NewIntPointsSet(KindTri, "internal_12")
+	X, W := o.Coords()
+	chk.Int(tst, "len(X)", len(X), 12)
+	chk.Int(tst, "len(W)", len(W), 12)
+	for i, p := range o.P {
+		chk.Array(tst, io.Sf("x%d", i), 1e-17, X[i], p[:2])
+		chk.Float64(tst, io.Sf("w%d", i), 1e-17, W[i], p[3])
+	}
+
+	// join
+	p, err := NewIntPointsSetFromCoords(KindTri, "internal_12", X, W)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Int(tst, "ndim", p.Ndim, 2)
+	chk.Int(tst, "npts", p.Npts, 12)
+	chk.Deep2(tst, "P", 1e-17, p.P, o.P)
+
+	// copies
+	X[0][0], W[0] = 123, 456
+	chk.Deep2(tst, "database", 1e-17, p.P, GetIntPoints()[KindTri]["internal_12"])
+
+	// wrong data
+	_, err = NewIntPointsSetFromCoords(KindHex, "a", X, W)
+	if err == nil {
+		tst.Errorf("coordinates should be incompatible with cell kind\n")
+		return
+	}
+	io.Pf("OK, caught the following error: %v", err)
+	_, err = NewIntPointsSetFromCoords(KindTri, "a", X, W[:3])
+	if err == nil {
+		tst.Errorf("number of weights should be incompatible\n")
+		return
+	}
+	io.Pf("OK, caught the following error: %v", err)
+}
+
+func TestQuadset08(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset08. validate")
+
+	// all sets in database
+	for kind, db := range GetIntPoints() {
+		for name := range db {
+			err := NewIntPointsSet(kind, name).Validate(1e-14)
+			if kind == KindTet && name == "internal_6" {
+				if err == nil {
+					tst.Errorf("tet:internal_6 should be invalid\n")
+				}
+				continue // NOTE: this set is not defined over the reference tetrahedron
+			}
+			if err != nil {
+				tst.Errorf("kind=%d, name=%q: %v\n", kind, name, err)
+			}
+		}
+	}
+
+	// corrupted sets
+	o := NewIntPointsSet(KindTri, "internal_3")
+	corrupt := map[string]func(p *IntPointsSet){
+		"npts":    func(p *IntPointsSet) { p.Npts = 4 },
+		"len":     func(p *IntPointsSet) { p.P[1] = p.P[1][:3] },
+		"ndim":    func(p *IntPointsSet) { p.Ndim = 3 },
+		"NaN":     func(p *IntPointsSet) { p.P[0][0] = math.NaN() },
+		"Inf":     func(p *IntPointsSet) { p.P[2][3] = math.Inf(1) },
+		"unused":  func(p *IntPointsSet) { p.P[0][2] = 0.1 },
+		"outside": func(p *IntPointsSet) { p.P[1][0], p.P[1][1] = 0.6, 0.6 },
+		"weights": func(p *IntPointsSet) { p.P[1][3] = 0.2 },
+	}
+	for key, fcn := range corrupt {
+		p := o.Clone()
+		fcn(p)
+		err := p.Validate(1e-14)
+		if err == nil {
+			tst.Errorf("%s: validation should have failed\n", key)
+			continue
+		}
+		io.Pf("%-8s: OK, caught the following error: %v", key, err)
+	}
+	err := QuadPointsValidate(o.P, KindNumMax, 1e-14)
+	if err == nil {
+		tst.Errorf("validation with invalid cell kind should have failed\n")
+	}
+}
+
+func TestQuadset09(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset09. scale weights and simplex mapping")
+
+	// scale weights
+	o := NewIntPointsSet(KindTri, "internal_7")
+	p := o.ScaleWeights(2)
+	chk.Float64(tst, "sum(w)", 1e-15, QuadPointsSumWeights(p.P), 1)
+	chk.Float64(tst, "database", 1e-15, QuadPointsSumWeights(o.P), 0.5)
+
+	// triangle with vertices (1,1), (4,2) and (2,5) => area = 5.5
+	p = o.ForSimplex([][]float64{{1, 1}, {4, 2}, {2, 5}})
+	chk.String(tst, p.Name, "internal_7-mapped")
+	chk.Float64(tst, "area", 1e-14, QuadPointsSumWeights(p.P), 5.5)
+	xc, yc := 0.0, 0.0
+	for _, q := range p.P {
+		xc += q[0] * q[3] / 5.5
+		yc += q[1] * q[3] / 5.5
+	}
+	chk.Float64(tst, "xc", 1e-14, xc, 7.0/3.0)
+	chk.Float64(tst, "yc", 1e-14, yc, 8.0/3.0)
+
+	// clockwise vertices
+	p = o.ForSimplex([][]float64{{1, 1}, {2, 5}, {4, 2}})
+	chk.Float64(tst, "area (cw)", 1e-14, QuadPointsSumWeights(p.P), 5.5)
+
+	// tetrahedron with edges 2, 3 and 4 along the axes => volume = 4
+	p = NewIntPointsSet(KindTet, "internal_4").ForSimplex([][]float64{{1, 1, 1}, {3, 1, 1}, {1, 4, 1}, {1, 1, 5}})
+	chk.Float64(tst, "volume", 1e-14, QuadPointsSumWeights(p.P), 4)
+
+	// invalid set
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		NewIntPointsSet(KindQua, "legendre_4").ForSimplex([][]float64{{0, 0}, {1, 0}, {0, 1}})
+	}()
+}
+
+func TestQuadset10(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset10. flat slices")
+
+	// flatten
+	o := NewIntPointsSet(KindHex, "legendre_8")
+	coords, weights := o.Flatten()
+	chk.Int(tst, "len(coords)", len(coords), 24)
+	chk.Int(tst, "len(weights)", len(weights), 8)
+	for i, p := range o.P {
+		chk.Array(tst, io.Sf("x%d", i), 1e-17, coords[i*3:i*3+3], p[:3])
+		chk.Float64(tst, io.Sf("w%d", i), 1e-17, weights[i], p[3])
+	}
+
+	// rebuild
+	p, err := NewIntPointsSetFromFlat(KindHex, "legendre_8", coords, weights)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Int(tst, "npts", p.Npts, 8)
+	chk.Deep2(tst, "P", 1e-17, p.P, o.P)
+
+	// wrong data
+	_, err = NewIntPointsSetFromFlat(KindQua, "a", coords, weights)
+	if err == nil {
+		tst.Errorf("coordinates should be incompatible with cell kind\n")
+		return
+	}
+	io.Pf("OK, caught the following error: %v", err)
+}
+
+func TestQuadset11(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset11. csv")
+
+	// write
+	o := NewIntPointsSet(KindTri, "internal_16")
+	var b bytes.Buffer
+	err := o.WriteCSV(&b)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pf("%s", b.String())
+	lines := strings.Split(b.String(), "\n")
+	chk.String(tst, lines[0], "x0,x1,weight")
+	chk.Int(tst, "number of lines", len(lines), 18) // header + 16 points + empty line
+
+	// read
+	p, err := ReadIntPointsSetCSV(&b, KindTri, "internal_16")
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Int(tst, "ndim", p.Ndim, 2)
+	chk.Int(tst, "npts", p.Npts, 16)
+	chk.Deep2(tst, "P", 1e-17, p.P, o.P)
+
+	// wrong data
+	for _, str := range []string{
+		"",
+		"x0,x1,weight\n0.1,0.2\n",
+		"x0,x1,weight\n0.1,0.2,abc\n",
+		"x0,weight\n0.1,0.2\n",
+	} {
+		_, err = ReadIntPointsSetCSV(strings.NewReader(str), KindTri, "a")
+		if err == nil {
+			tst.Errorf("reading should have failed with %q\n", str)
+			return
+		}
+		io.Pf("OK, caught the following error: %v", err)
+	}
+}
+
+func TestQuadset12(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset12. set by degree")
+
+	o, err := NewIntPointsSetByDegree(KindQua, 2)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.String(tst, o.Name, "legendre_4")
+	chk.Int(tst, "npts", o.Npts, 4)
+	chk.Int(tst, "degree", o.Degree(), 3)
+
+	o, err = NewIntPointsSetByDegree(KindHex, 9)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.String(tst, o.Name, "legendre_125")
+	chk.Int(tst, "npts", o.Npts, 125)
+
+	_, err = NewIntPointsSetByDegree(KindTet, 6)
+	if err == nil {
+		tst.Errorf("NewIntPointsSetByDegree should have failed\n")
+	}
+}
+
+func TestQuadset13(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset13. measure of reference cells")
+
+	measures := []float64{
+		KindLin: 2,
+		KindTri: 1.0 / 2.0,
+		KindQua: 4,
+		KindTet: 1.0 / 6.0,
+		KindHex: 8,
+		KindWed: 1,
+		KindPyr: 4.0 / 3.0,
+	}
+	chk.Int(tst, "number of kinds", len(measures), KindNumMax)
+	for kind, correct := range measures {
+		measure, err := ReferenceMeasure(kind)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		chk.Float64(tst, io.Sf("kind = %d: measure", kind), 1e-15, measure, correct)
+	}
+
+	for _, kind := range []int{-1, KindNumMax} {
+		_, err := ReferenceMeasure(kind)
+		if err == nil {
+			tst.Errorf("ReferenceMeasure should have failed for kind = %d\n", kind)
+			return
+		}
+		io.Pf("OK, caught the following error: %v", err)
+	}
+}
+
+func TestQuadset14(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset14. iterate over points")
+
+	o := NewIntPointsSet(KindQua, "legendre_9")
+	P := o.Clone().P
+	X, W := o.Coords()
+	count := 0
+	o.Iterate(func(i int, x []float64, w float64) bool {
+		chk.Int(tst, "i", i, count)
+		chk.Array(tst, io.Sf("x%d", i), 1e-17, x, X[i])
+		chk.Float64(tst, io.Sf("w%d", i), 1e-17, w, W[i])
+		x[0] = 123 // must not affect set
+		count++
+		return true
+	})
+	chk.Int(tst, "count", count, 9)
+	chk.Deep2(tst, "P", 1e-17, o.P, P)
+
+	// collect coordinates
+	var C [][]float64
+	o.Iterate(func(i int, x []float64, w float64) bool {
+		C = append(C, x)
+		return true
+	})
+	chk.Deep2(tst, "C", 1e-17, C, X)
+
+	// early exit
+	count = 0
+	o.Iterate(func(i int, x []float64, w float64) bool {
+		count++
+		return i < 4
+	})
+	chk.Int(tst, "count", count, 5)
+}
+
+func TestQuadset15(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset15. default sets")
+
+	npts := []int{
+		KindLin: 2,
+		KindTri: 3,
+		KindQua: 4,
+		KindTet: 4,
+		KindHex: 8,
+		KindWed: 6,
+		KindPyr: 8,
+	}
+	for kind := 0; kind < KindNumMax; kind++ {
+		o := NewIntPointsSetDefault(kind)
+		io.Pforan("kind = %d: %s\n", kind, o.Name)
+		chk.Int(tst, "npts", o.Npts, npts[kind])
+		measure, _ := ReferenceMeasure(kind)
+		chk.Float64(tst, "sum of weights", 1e-14, QuadPointsSumWeights(o.P), measure)
+	}
+
+	// same as default for linear cell types
+	chk.Deep2(tst, "lin", 1e-17, NewIntPointsSetDefault(KindLin).P, GetDefaultIntPoints()[TypeLin2])
+	chk.Deep2(tst, "tri", 1e-17, NewIntPointsSetDefault(KindTri).P, GetDefaultIntPoints()[TypeTri3])
+	chk.Deep2(tst, "qua", 1e-17, NewIntPointsSetDefault(KindQua).P, GetDefaultIntPoints()[TypeQua4])
+	chk.Deep2(tst, "tet", 1e-17, NewIntPointsSetDefault(KindTet).P, GetDefaultIntPoints()[TypeTet4])
+	chk.Deep2(tst, "hex", 1e-17, NewIntPointsSetDefault(KindHex).P, GetDefaultIntPoints()[TypeHex8])
+
+	// invalid kind
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		NewIntPointsSetDefault(KindNumMax)
+	}()
+}
+
+func TestQuadset16(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset16. reduced and selective sets")
+
+	o := NewIntPointsSetReduced(KindQua)
+	chk.Int(tst, "npts", o.Npts, 1)
+	chk.Array(tst, "qua: P[0]", 1e-15, o.P[0], []float64{0, 0, 0, 4})
+
+	centroids := [][]float64{
+		KindLin: {0, 0, 0},
+		KindTri: {1.0 / 3.0, 1.0 / 3.0, 0},
+		KindQua: {0, 0, 0},
+		KindTet: {1.0 / 4.0, 1.0 / 4.0, 1.0 / 4.0},
+		KindHex: {0, 0, 0},
+		KindWed: {1.0 / 3.0, 1.0 / 3.0, 0},
+		KindPyr: {0, 0, 1.0 / 4.0},
+	}
+	for kind := 0; kind < KindNumMax; kind++ {
+		full, reduced := NewIntPointsSetsSelective(kind)
+		io.Pforan("kind = %d: full = %s, reduced = %s\n", kind, full.Name, reduced.Name)
+		chk.Int(tst, "npts", reduced.Npts, 1)
+		measure, _ := ReferenceMeasure(kind)
+		chk.Array(tst, io.Sf("kind = %d: P[0]", kind), 1e-15, reduced.P[0], append(centroids[kind], measure))
+		chk.String(tst, full.Name, NewIntPointsSetDefault(kind).Name)
+		if reduced.Degree() >= full.Degree() {
+			tst.Errorf("degree of reduced set must be smaller than degree of full set\n")
+			return
+		}
+	}
+}
+
+func TestQuadset17(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset17. report")
+
+	// sets with negative weights
+	negative := map[int][]string{
+		KindTri: {"internal_4", "internal_13"},
+		KindTet: {"internal_5", "internal_11"},
+	}
+
+	// all sets in database
+	for kind, db := range GetIntPoints() {
+		for name := range db {
+			r := NewIntPointsSet(kind, name).Report(24, 1e-15)
+			io.Pf("%v", r)
+			bogus := kind == KindTet && name == "internal_6" // see IntPointsFindDegree
+			chk.Int(tst, "degree", r.ExactDegree, r.Degree)
+			if r.AllInside == bogus {
+				tst.Errorf("%d:%s: AllInside is incorrect\n", kind, name)
+			}
+			if r.NegativeWeights != (utl.StrIndexSmall(negative[kind], name) >= 0) {
+				tst.Errorf("%d:%s: NegativeWeights is incorrect\n", kind, name)
+			}
+			if !bogus {
+				chk.Float64(tst, "sum of weights", 1e-14, r.SumWeights, r.Measure)
+			}
+		}
+	}
+
+	// generated set
+	r := NewIntPointsSet(KindQua, "legendre_36").Report(24, 1e-15)
+	chk.Int(tst, "degree", r.Degree, 11)
+	chk.Int(tst, "exact degree", r.ExactDegree, 11)
+	chk.Float64(tst, "Wmin", 1e-15, r.Wmin, math.Pow(0.1713244923791704, 2))
+	chk.Float64(tst, "Wmax", 1e-15, r.Wmax, math.Pow(0.4679139345726910, 2))
+
+	// unknown set
+	o, err := NewIntPointsSetFromCoords(KindLin, "mine", [][]float64{{-0.5}, {0.5}}, []float64{1, 1})
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	r = o.Report(10, 0)
+	chk.Int(tst, "degree", r.Degree, -1)
+	chk.Int(tst, "exact degree", r.ExactDegree, 1)
+	chk.String(tst, r.String(), `kind = 0, name = "mine", npts = 2
+  degree (declared) = -1
+  degree (measured) = 1
+  sum of weights    = 2 (measure = 2)
+  min/max weight    = 1 / 1
+  negative weights  = false
+  all inside cell   = true
+`)
+}
+
+func TestQuadset18(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset18. equality of sets")
+
+	// tensor product of lin sets
+	lin := NewIntPointsSet(KindLin, "legendre_2")
+	X, W := QuadPointsToCoords(QuadPointsTensorProduct(lin.P, 1, lin.P, 1), 2)
+	a, err := NewIntPointsSetFromCoords(KindQua, "lin2xlin2", X, W)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	b := NewIntPointsSet(KindQua, "legendre_4")
+	if !a.Equal(b, 1e-15) || !b.Equal(a, 1e-15) {
+		tst.Errorf("sets should be equal\n")
+	}
+
+	// reversed order
+	c := b.Clone()
+	for i, j := 0, c.Npts-1; i < j; i, j = i+1, j-1 {
+		c.P[i], c.P[j] = c.P[j], c.P[i]
+	}
+	if !c.Equal(b, 1e-15) {
+		tst.Errorf("sets should be equal\n")
+	}
+
+	// different sets
+	if a.Equal(lin, 1e-15) {
+		tst.Errorf("sets with different kinds should not be equal\n")
+	}
+	if b.Equal(NewIntPointsSet(KindQua, "legendre_9"), 1e-15) {
+		tst.Errorf("sets with different number of points should not be equal\n")
+	}
+	c.P[0][3] += 1e-10
+	if c.Equal(b, 1e-15) {
+		tst.Errorf("sets with different weights should not be equal\n")
+	}
+	if !c.Equal(b, 1e-9) {
+		tst.Errorf("sets should be equal within tolerance\n")
+	}
+}
+
+func TestQuadset19(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset19. sets over faces")
+
+	// hex: all faces are squares with area 4
+	for faceIndex := 0; faceIndex < 6; faceIndex++ {
+		face, mapToParent := NewIntPointsSetFace(KindHex, faceIndex, "legendre_4")
+		chk.Int(tst, "face: kind", face.Kind, KindQua)
+		chk.Float64(tst, io.Sf("hex: face %d: area", faceIndex), 1e-15, QuadPointsSumWeights(face.P), 4)
+
+		// mapped points are on the face
+		m := FaceLocalVerts[TypeHex8][faceIndex]
+		for _, p := range face.P {
+			x := mapToParent(p[:2])
+			chk.Int(tst, "len(x)", len(x), 3)
+			for j := 0; j < 3; j++ {
+				c := NatCoords[TypeHex8][j][m[0]]
+				if c == NatCoords[TypeHex8][j][m[1]] && c == NatCoords[TypeHex8][j][m[2]] {
+					chk.Float64(tst, io.Sf("hex: face %d: x%d", faceIndex, j), 1e-15, x[j], c)
+				}
+			}
+		}
+	}
+
+	// ∫ x dA over the face at x=+1 is 4 and over the face at x=-1 is -4
+	for faceIndex, correct := range []float64{-4, 4} {
+		face, mapToParent := NewIntPointsSetFace(KindHex, faceIndex, "legendre_4")
+		res := QuadPointsIntegrate(face.P, 2, func(ξ la.Vector) float64 { return mapToParent(ξ)[0] })
+		chk.Float64(tst, io.Sf("hex: face %d: ∫x", faceIndex), 1e-15, res, correct)
+	}
+
+	// qua: all edges have length 2
+	for faceIndex := 0; faceIndex < 4; faceIndex++ {
+		face, _ := NewIntPointsSetFace(KindQua, faceIndex, "legendre_2")
+		chk.Float64(tst, io.Sf("qua: edge %d: length", faceIndex), 1e-15, QuadPointsSumWeights(face.P), 2)
+	}
+
+	// tri: edges
+	for faceIndex, correct := range []float64{1, math.Sqrt2, 1} {
+		face, mapToParent := NewIntPointsSetFace(KindTri, faceIndex, "legendre_2")
+		chk.Float64(tst, io.Sf("tri: edge %d: length", faceIndex), 1e-15, QuadPointsSumWeights(face.P), correct)
+		for _, p := range face.P {
+			x := mapToParent(p[:1])
+			if !InReferenceCell(KindTri, x, 1e-15) {
+				tst.Errorf("mapped point %v is outside reference triangle\n", x)
+				return
+			}
+		}
+	}
+
+	// tet: faces
+	for faceIndex, correct := range []float64{0.5, 0.5, 0.5, math.Sqrt(3) / 2} {
+		face, _ := NewIntPointsSetFace(KindTet, faceIndex, "internal_3")
+		chk.Int(tst, "face: kind", face.Kind, KindTri)
+		chk.Float64(tst, io.Sf("tet: face %d: area", faceIndex), 1e-15, QuadPointsSumWeights(face.P), correct)
+	}
+
+	// errors
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		NewIntPointsSetFace(KindHex, 6, "legendre_4")
+	}()
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		NewIntPointsSetFace(KindLin, 0, "legendre_1")
+	}()
+}
+
+func TestQuadset20(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset20. metadata")
+
+	o := NewIntPointsSet(KindQua, "wilson8default_8")
+	io.Pforan("source = %q\n", o.Source)
+	io.Pforan("recommended for = %q\n", o.RecommendedFor)
+	chk.String(tst, o.Source, "Wilson's Appendix G-7 formulae (8-point rule)")
+	if o.RecommendedFor == "" {
+		tst.Errorf("RecommendedFor should not be empty\n")
+	}
+
+	o = NewIntPointsSet(KindHex, "legendre_125")
+	chk.String(tst, o.Source, "Gauss-Legendre rule (tensor product along each direction)")
+
+	o = NewIntPointsSet(KindTet, "internal_11")
+	if !strings.HasPrefix(o.Source, "Keast P (1986)") {
+		tst.Errorf("source of tet internal_11 is incorrect: %q\n", o.Source)
+	}
+
+	// metadata is kept in json files
+	b, err := json.Marshal(o)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	var p IntPointsSet
+	err = json.Unmarshal(b, &p)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.String(tst, p.Source, o.Source)
+	chk.String(tst, p.RecommendedFor, o.RecommendedFor)
+
+	// user-defined set
+	u, err := NewIntPointsSetFromCoords(KindLin, "mine", [][]float64{{0}}, []float64{2})
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.String(tst, u.Source, "")
+	chk.String(tst, u.RecommendedFor, "")
+	b, err = json.Marshal(u)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.String(tst, string(b), `{"kind":0,"name":"mine","ndim":1,"npts":1,"p":[[0,0,0,2]]}`)
+}
+
+func TestQuadset21(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset21. ndim inferred from shape")
+
+	// tensor-product rules
+	o, err := NewIntPointsSetForShape("hex", "legendre", 8, nil)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Int(tst, "hex: kind", o.Kind, KindHex)
+	chk.Int(tst, "hex: ndim", o.Ndim, 3)
+	chk.Int(tst, "hex: npts", o.Npts, 8)
+	chk.Float64(tst, "hex: sum(w)", 1e-14, QuadPointsSumWeights(o.P), 8)
+	chk.Deep2(tst, "hex: P", 1e-15, o.P, NewIntPointsSet(KindHex, "legendre_8").P)
+
+	o, err = NewIntPointsSetForShape("qua8", "lobatto", 9, nil)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Int(tst, "qua8: ndim", o.Ndim, 2)
+	chk.String(tst, o.Name, "lobatto_9")
+
+	o, err = NewIntPointsSetForShape("lin", "newtoncotes", 3, nil)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Int(tst, "lin: ndim", o.Ndim, 1)
+	chk.Array(tst, "lin: w", 1e-15, []float64{o.P[0][3], o.P[1][3], o.P[2][3]}, []float64{1.0 / 3.0, 4.0 / 3.0, 1.0 / 3.0})
+
+	// Wilson's rules
+	prms := dbf.NewParams(&dbf.P{N: "stable", V: 1})
+	o, err = NewIntPointsSetForShape("qua", "wilson5", 5, prms)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Deep2(tst, "wilson5 stable", 1e-15, o.P, QuadPointsWilson5(0, true))
+	o, err = NewIntPointsSetForShape("hex20", "wilson9", 9, nil)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Deep2(tst, "wilson9", 1e-15, o.P, QuadPointsWilson9(0, false))
+
+	// database
+	o, err = NewIntPointsSetForShape("tri6", "", 3, nil)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Int(tst, "tri: ndim", o.Ndim, 2)
+	chk.Int(tst, "tri: npts", o.Npts, 3)
+
+	// number of points along each direction
+	for _, c := range []struct {
+		shape, rule string
+		npts        int
+	}{
+		{"lin", "legendre", 1},
+		{"qua", "radauleft", 1},
+		{"hex", "radauright", 1},
+		{"lin", "lobatto", 2},
+		{"lin", "newtoncotes", 2},
+		{"qua", "newtoncotes", 36},
+		{"lin", "newtoncotes", 7},
+		{"lin", "newtoncotesopen", 1},
+		{"hex", "newtoncotesopen", 125},
+	} {
+		o, err = NewIntPointsSetForShape(c.shape, c.rule, c.npts, nil)
+		if err != nil {
+			tst.Errorf("%s with rule %q and npts=%d failed: %v\n", c.shape, c.rule, c.npts, err)
+			return
+		}
+		chk.Int(tst, io.Sf("%s %s: npts", c.shape, c.rule), o.Npts, c.npts)
+		chk.Float64(tst, io.Sf("%s %s: sum(w)", c.shape, c.rule), 1e-13, QuadPointsSumWeights(o.P), math.Pow(2, float64(o.Ndim)))
+	}
+
+	// errors
+	_, err = NewIntPointsSetForShape("hex", "wilson5", 5, nil)
+	if err == nil {
+		tst.Errorf("wilson5 should have been rejected for hex\n")
+		return
+	}
+	io.Pforan("%v", err)
+	if !strings.Contains(err.Error(), "requires a qua shape") {
+		tst.Errorf("error message is not clear: %v\n", err)
+	}
+	for _, c := range []struct {
+		shape, rule string
+		npts        int
+	}{
+		{"abc", "legendre", 4},
+		{"tri", "legendre", 4},
+		{"qua", "legendre", 8},
+		{"qua", "newtoncotes", 64},
+		{"lin", "newtoncotes", 8},
+		{"lin", "newtoncotes", 1},
+		{"qua", "newtoncotes", 1},
+		{"lin", "newtoncotesopen", 6},
+		{"lin", "lobatto", 1},
+		{"hex", "lobatto", 1},
+		{"lin", "legendre", 0},
+		{"lin", "radauleft", 0},
+		{"qua", "wilson8", 9},
+		{"qua", "wilson9", 9},
+		{"tet", "", 1000},
+		{"lin", "unknown", 2},
+	} {
+		_, err = NewIntPointsSetForShape(c.shape, c.rule, c.npts, nil)
+		if err == nil {
+			tst.Errorf("%s with rule %q and npts=%d should have failed\n", c.shape, c.rule, c.npts)
+		}
+	}
+}
+
+func TestQuadset22(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset22. map to unit interval")
+
+	// qua
+	o := NewIntPointsSet(KindQua, "legendre_4").ToUnitInterval()
+	io.Pforan("%v\n", o)
+	chk.String(tst, o.Name, "legendre_4-unit")
+	chk.Float64(tst, "sum(w)", 1e-15, QuadPointsSumWeights(o.P), 1)
+	xmin, xmax := o.Bounds()
+	for i := 0; i < 2; i++ {
+		if xmin[i] <= 0 || xmax[i] >= 1 {
+			tst.Errorf("points must be inside [0,1]²\n")
+			return
+		}
+	}
+	chk.Float64(tst, "∫x", 1e-15, QuadPointsIntegrate(o.P, 2, func(x la.Vector) float64 { return x[0] }), 0.5)
+	chk.Float64(tst, "∫x²y", 1e-15, QuadPointsIntegrate(o.P, 2, func(x la.Vector) float64 { return x[0] * x[0] * x[1] }), 1.0/6.0)
+
+	// lin and hex
+	chk.Array(tst, "lin: x", 1e-15, NewIntPointsSet(KindLin, "legendre_2").ToUnitInterval().P[0], []float64{0.5 - 0.5/math.Sqrt(3), 0, 0, 0.5})
+	p := NewIntPointsSet(KindHex, "legendre_27").ToUnitInterval()
+	chk.Float64(tst, "hex: sum(w)", 1e-15, QuadPointsSumWeights(p.P), 1)
+	chk.Float64(tst, "hex: ∫xyz", 1e-15, QuadPointsIntegrate(p.P, 3, func(x la.Vector) float64 { return x[0] * x[1] * x[2] }), 1.0/8.0)
+
+	// error
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		NewIntPointsSet(KindTri, "internal_3").ToUnitInterval()
+	}()
+}
+
+func TestQuadset23(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset23. append sets")
+
+	// two lin rules
+	dst := NewIntPointsSet(KindLin, "legendre_2")
+	src := NewIntPointsSet(KindLin, "legendre_3").ScaleWeights(0.5)
+	err := src.AppendTo(dst)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Int(tst, "npts", dst.Npts, 5)
+	chk.Int(tst, "len(P)", len(dst.P), 5)
+	chk.Float64(tst, "sum(w)", 1e-15, QuadPointsSumWeights(dst.P), 3)
+	chk.Array(tst, "P[2]", 1e-15, dst.P[2], []float64{-math.Sqrt(0.6), 0, 0, 5.0 / 18.0})
+
+	// database is not modified
+	chk.Int(tst, "database: npts", len(GetIntPoints()[KindLin]["legendre_2"]), 2)
+	dst.P[4][3] = 123
+	chk.Float64(tst, "source", 1e-15, src.P[2][3], 5.0/18.0)
+
+	// error
+	err = NewIntPointsSet(KindQua, "legendre_4").AppendTo(dst)
+	if err == nil {
+		tst.Errorf("appending qua to lin should have failed\n")
+	}
+}
+
+func TestQuadset24(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset24. available sets")
+
+	// qua
+	sets := IntPointsAvailable(KindQua)
+	io.Pforan("%v\n", sets)
+	names := make([]string, len(sets))
+	for i, s := range sets {
+		names[i] = s.Name
+		chk.Int(tst, s.Name, s.Npts, len(NewIntPointsSet(KindQua, s.Name).P))
+		if i > 0 && s.Npts < sets[i-1].Npts {
+			tst.Errorf("sets must be sorted by number of points\n")
+			return
+		}
+	}
+	for _, name := range []string{"legendre_4", "legendre_9", "wilson5corner_5", "wilson5stable_5", "wilson8default_8"} {
+		if utl.StrIndexSmall(names, name) < 0 {
+			tst.Errorf("set %q should be available for qua\n", name)
+		}
+	}
+
+	// registered set
+	o := NewIntPointsSet(KindTri, "internal_3").Clone()
+	o.Name = "custom_3"
+	err := IntPointsRegister(o, false)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	defer delete(GetIntPoints()[KindTri], o.Name)
+	found := false
+	for _, s := range IntPointsAvailable(KindTri) {
+		if s.Name == "custom_3" && s.Npts == 3 {
+			found = true
+		}
+	}
+	if !found {
+		tst.Errorf("registered set should be available\n")
+	}
+
+	// invalid kind
+	chk.Int(tst, "invalid kind", len(IntPointsAvailable(-1)), 0)
+}
+
+func TestQuadset25(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset25. auto-tuned Gauss-Legendre sets")
+
+	// cos(x) over the reference line
+	o, res, err := NewIntPointsSetAutoTuned(func(x la.Vector) float64 { return math.Cos(x[0]) }, 1, 1e-10, 20)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("%s: res = %v\n", o.Name, res)
+	chk.Float64(tst, "∫cos(x)", 1e-12, res, 2*math.Sin(1))
+	if o.Npts > 8 {
+		tst.Errorf("a low order should have been selected. npts = %d is too high\n", o.Npts)
+	}
+	chk.Int(tst, "kind", o.Kind, KindLin)
+
+	// polynomial over the reference square: exact with 2 points along each direction
+	o, res, err = NewIntPointsSetAutoTuned(func(x la.Vector) float64 { return x[0] * x[0] * x[1] * x[1] }, 2, 1e-14, 10)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.String(tst, o.Name, "legendre_9")
+	chk.Int(tst, "kind", o.Kind, KindQua)
+	chk.Float64(tst, "∫x²y²", 1e-15, res, 4.0/9.0)
+
+	// errors
+	_, _, err = NewIntPointsSetAutoTuned(func(x la.Vector) float64 { return math.Sqrt(math.Abs(x[0])) }, 1, 1e-14, 3)
+	if err == nil {
+		tst.Errorf("tolerance should not have been met\n")
+	}
+	_, _, err = NewIntPointsSetAutoTuned(func(x la.Vector) float64 { return 1 }, 4, 1e-14, 3)
+	if err == nil {
+		tst.Errorf("ndim=4 should have failed\n")
+	}
+}
+
+func TestQuadset26(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset26. estimate of errors")
+
+	// smooth integrand over the reference line
+	f := func(x la.Vector) float64 { return math.Exp(x[0]) * math.Cos(x[0]) }
+	prev := math.Inf(1)
+	for n := 2; n <= 6; n++ {
+		o := NewIntPointsSet(KindLin, io.Sf("legendre_%d", n))
+		estimate, err := o.EstimateError(f)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		actual := math.Abs(QuadPointsIntegrate(o.P, 1, f) - QuadPointsIntegrate(QuadPointsGaussLegendre(1, 20), 1, f))
+		io.Pforan("n = %d: estimate = %.3e  actual = %.3e\n", n, estimate, actual)
+		if estimate >= prev {
+			tst.Errorf("estimate should decrease with the number of points\n")
+			return
+		}
+		if estimate < actual {
+			tst.Errorf("estimate should be greater than the actual error for this smooth function\n")
+			return
+		}
+		prev = estimate
+	}
+
+	// qua
+	o := NewIntPointsSet(KindQua, "legendre_9")
+	estimate, err := o.EstimateError(func(x la.Vector) float64 { return x[0] * x[0] * x[1] * x[1] })
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "qua: x²y²", 1e-15, estimate, 0)
+
+	// errors
+	for _, o := range []*IntPointsSet{
+		NewIntPointsSet(KindLin, "legendre_1"),
+		NewIntPointsSet(KindTri, "internal_3"),
+		NewIntPointsSet(KindQua, "wilson5corner_5"),
+	} {
+		_, err = o.EstimateError(f)
+		if err == nil {
+			tst.Errorf("%q should have failed\n", o.Name)
+		}
+	}
+}
+
+func TestQuadset27(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset27. split by sign of weights")
+
+	// tet with 5 points
+	o := NewIntPointsSet(KindTet, "internal_5")
+	pos, neg := o.SplitByWeightSign()
+	chk.Int(tst, "positive: npts", pos.Npts, 4)
+	chk.Int(tst, "negative: npts", neg.Npts, 1)
+	chk.Int(tst, "positive: len(P)", len(pos.P), 4)
+	chk.String(tst, pos.Name, "internal_5-positive")
+	chk.String(tst, neg.Name, "internal_5-negative")
+	chk.Float64(tst, "sum(w)", 1e-15, QuadPointsSumWeights(pos.P)+QuadPointsSumWeights(neg.P), 1.0/6.0)
+	if pos.HasNegativeWeights() || neg.P[0][3] >= 0 {
+		tst.Errorf("points were not split correctly\n")
+	}
+	chk.Array(tst, "negative: centroid", 1e-15, neg.P[0][:3], []float64{0.25, 0.25, 0.25})
+
+	// copies
+	neg.P[0][3] = 0
+	if o.P[0][3] == 0 {
+		tst.Errorf("points must be copied\n")
+	}
+
+	// zero weights go with positive
+	pp, pn := QuadPointsSplitByWeightSign([][]float64{{0, 0, 0, 0}, {1, 0, 0, -1}, {2, 0, 0, 1}})
+	chk.Deep2(tst, "pp", 1e-17, pp, [][]float64{{0, 0, 0, 0}, {2, 0, 0, 1}})
+	chk.Deep2(tst, "pn", 1e-17, pn, [][]float64{{1, 0, 0, -1}})
+
+	// only positive
+	pos, neg = NewIntPointsSet(KindQua, "legendre_4").SplitByWeightSign()
+	chk.Int(tst, "legendre_4: positive", pos.Npts, 4)
+	chk.Int(tst, "legendre_4: negative", neg.Npts, 0)
+}
+
+func TestQuadset28(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset28. integrate fun and dbf functions")
+
+	// fun.Sv over the reference line: ∫ (x⁵ + x⁴ + 1) dx = 2/5 + 2
+	o := NewIntPointsSet(KindLin, "legendre_3")
+	var f fun.Sv = func(x la.Vector) float64 { return math.Pow(x[0], 5) + math.Pow(x[0], 4) + 1 }
+	chk.Float64(tst, "lin: ∫f", 1e-15, o.IntegrateFunc(f), 2.0/5.0+2.0)
+
+	// dbf over the reference line: ∫ (2 x + 3 x²) dx = 2
+	g := dbf.New("xpoly2", dbf.NewParams(
+		&dbf.P{N: "a0", V: 2}, &dbf.P{N: "a1", V: 0}, &dbf.P{N: "a2", V: 0},
+		&dbf.P{N: "b0", V: 3}, &dbf.P{N: "b1", V: 0}, &dbf.P{N: "b2", V: 0},
+		&dbf.P{N: "c01", V: 0}, &dbf.P{N: "c12", V: 0}, &dbf.P{N: "c20", V: 0},
+	))
+	chk.Float64(tst, "lin: ∫g", 1e-15, o.IntegrateDbf(g, 0), 2.0)
+
+	// dbf over the reference triangle: ∫ x y dΩ = 1/24
+	h := dbf.New("xpoly2", dbf.NewParams(
+		&dbf.P{N: "2D", V: 1},
+		&dbf.P{N: "a0", V: 0}, &dbf.P{N: "a1", V: 0},
+		&dbf.P{N: "b0", V: 0}, &dbf.P{N: "b1", V: 0},
+		&dbf.P{N: "c01", V: 1},
+	))
+	chk.Float64(tst, "tri: ∫h", 1e-15, NewIntPointsSet(KindTri, "internal_3").IntegrateDbf(h, 0), 1.0/24.0)
+}
+
+func TestQuadset29(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset29. shape of sets")
+
+	// constructors
+	gauss, kronrod := NewIntPointsSetsKronrod(3)
+	fromCoords, _ := NewIntPointsSetFromCoords(KindTri, "a", [][]float64{{1.0 / 3.0, 1.0 / 3.0}}, []float64{0.5})
+	byDegree, _ := NewIntPointsSetByDegree(KindTet, 2)
+	forShape, _ := NewIntPointsSetForShape("hex8", "legendre", 8, nil)
+	autoTuned, _, _ := NewIntPointsSetAutoTuned(func(x la.Vector) float64 { return x[0] * x[1] }, 2, 1e-10, 4)
+	full, reduced := NewIntPointsSetsSelective(KindPyr)
+	for _, c := range []struct {
+		o     *IntPointsSet
+		shape string
+	}{
+		{NewIntPointsSet(KindQua, "legendre_4"), "qua"},
+		{NewIntPointsSetDefault(KindTri), "tri"},
+		{NewIntPointsSetReduced(KindHex), "hex"},
+		{NewIntPointsSetLattice(KindTet, 3), "tet"},
+		{NewIntPointsSetMonteCarlo(KindWed, 10, 1), "wed"},
+		{NewIntPointsSetQuasiMonteCarlo(KindTri, 10, "sobol"), "tri"},
+		{gauss, "lin"},
+		{kronrod, "lin"},
+		{fromCoords, "tri"},
+		{byDegree, "tet"},
+		{forShape, "hex"},
+		{autoTuned, "qua"},
+		{full, "pyr"},
+		{reduced, "pyr"},
+	} {
+		chk.String(tst, c.o.Shape(), c.shape)
+		chk.Int(tst, c.o.Name+": ndim", c.o.Ndim, kindNdim(c.o.Kind))
+	}
+
+	// tri and qua are distinguishable
+	tri, qua := NewIntPointsSet(KindTri, "internal_4"), NewIntPointsSet(KindQua, "legendre_4")
+	if tri.Equal(&IntPointsSet{Kind: KindQua, Name: tri.Name, Ndim: 2, Npts: tri.Npts, P: tri.P}, 1e-15) {
+		tst.Errorf("tri and qua sets must not be equal\n")
+	}
+	if err := tri.AppendTo(qua); err == nil {
+		tst.Errorf("appending tri set to qua set should have failed\n")
+	}
+	var p IntPointsSet
+	if err := json.Unmarshal([]byte(`{"kind":1,"name":"a","ndim":3,"npts":1,"p":[[0,0,0,1]]}`), &p); err == nil {
+		tst.Errorf("tri set with ndim=3 should have failed\n")
+	}
+
+	// errors
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		(&IntPointsSet{Kind: KindNumMax}).Shape()
+	}()
+}
+
+func TestQuadset30(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset30. refinement of nested sets")
+
+	// Gauss (Gauss-Patterson level 1) → Kronrod (Gauss-Patterson level 2)
+	f := func(x float64) float64 { return math.Exp(x) }
+	o := NewIntPointsSet(KindLin, "legendre_3")
+	refined, newPoints := o.Refine()
+	chk.String(tst, refined.Name, "kronrod_7")
+	chk.Ints(tst, "new points", newPoints, []int{0, 2, 4, 6})
+
+	// reuse values
+	values := make([]float64, refined.Npts)
+	for i, p := range refined.P {
+		for _, q := range o.P {
+			if math.Abs(p[0]-q[0]) < 1e-14 {
+				values[i] = f(q[0]) // value computed with the coarse set
+			}
+		}
+	}
+	for _, i := range newPoints {
+		values[i] = f(refined.P[i][0])
+	}
+	chk.Float64(tst, "∫exp", 1e-12, refined.IntegrateValues(values), math.E-1/math.E)
+
+	// Clenshaw-Curtis
+	o = NewIntPointsSetClenshawCurtis(1)
+	chk.String(tst, o.Name, "clenshawcurtis_1")
+	for _, c := range []struct {
+		name string
+		nnew int
+	}{
+		{"clenshawcurtis_3", 2},
+		{"clenshawcurtis_5", 2},
+		{"clenshawcurtis_9", 4},
+		{"clenshawcurtis_17", 8},
+	} {
+		refined, newPoints = o.Refine()
+		chk.String(tst, refined.Name, c.name)
+		chk.Int(tst, c.name+": new points", len(newPoints), c.nnew)
+		chk.Float64(tst, c.name+": ∫1", 1e-14, QuadPointsSumWeights(refined.P), 2)
+		o = refined
+	}
+	chk.Float64(tst, "∫exp", 1e-14, o.IntegrateFunc(func(x la.Vector) float64 { return f(x[0]) }), math.E-1/math.E)
+
+	// errors
+	_, kronrod := NewIntPointsSetsKronrod(3)
+	notLevel := &IntPointsSet{Kind: KindLin, Name: "clenshawcurtis_4", Ndim: 1, Npts: 1, P: [][]float64{{0, 0, 0, 2}}}
+	notNested := &IntPointsSet{Kind: KindLin, Name: "clenshawcurtis_3", Ndim: 1, Npts: 1, P: [][]float64{{0.1, 0, 0, 2}}}
+	for _, o = range []*IntPointsSet{kronrod, NewIntPointsSet(KindQua, "legendre_4"), notLevel, notNested} {
+		func() {
+			defer chk.RecoverTstPanicIsOK(tst)
+			o.Refine()
+		}()
+	}
+}
+
+func TestQuadset31(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset31. weights")
+
+	// copy
+	o := NewIntPointsSet(KindQua, "legendre_9")
+	a, b, c := 25.0/81.0, 40.0/81.0, 64.0/81.0
+	W := o.Weights()
+	chk.Array(tst, "W", 1e-15, W, []float64{a, b, a, b, c, b, a, b, a})
+	W[0] = 123
+	chk.Float64(tst, "w0", 1e-17, o.P[0][3], a)
+	chk.Float64(tst, "w0", 1e-17, NewIntPointsSet(KindQua, "legendre_9").Weights()[0], a)
+
+	// view
+	V := o.WeightsView()
+	chk.Array(tst, "V", 1e-15, V, []float64{a, b, a, b, c, b, a, b, a})
+	if U := o.WeightsView(); &U[0] != &V[0] {
+		tst.Errorf("view should not be allocated again\n")
+	}
+	U := NewIntPointsSet(KindQua, "legendre_4").WeightsView()
+	chk.Array(tst, "U", 1e-15, U, []float64{1, 1, 1, 1})
+	U = o.ScaleWeights(2).WeightsView()
+	chk.Array(tst, "scaled", 1e-15, U, []float64{2 * a, 2 * b, 2 * a, 2 * b, 2 * c, 2 * b, 2 * a, 2 * b, 2 * a})
+	chk.Array(tst, "V", 1e-15, o.WeightsView(), []float64{a, b, a, b, c, b, a, b, a})
+	o.P = QuadPointsGaussLegendre(2, 4)
+	chk.Array(tst, "new P", 1e-15, o.WeightsView(), []float64{1, 1, 1, 1})
+	if len((&IntPointsSet{}).WeightsView()) != 0 {
+		tst.Errorf("view of empty set should be empty\n")
+	}
+}
+
+func TestQuadset32(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset32. under-integration")
+
+	// 1-point rule with degree-2 integrand
+	f := func(x la.Vector) float64 { return x[0] * x[0] }
+	o := NewIntPointsSet(KindQua, "legendre_1")
+	err := o.WarnIfInsufficient(2)
+	if err == nil {
+		tst.Errorf("legendre_1 should be insufficient for degree 2\n")
+		return
+	}
+	io.Pforan("%v\n", err)
+	if !strings.Contains(err.Error(), `"legendre_4"`) {
+		tst.Errorf("error message should suggest legendre_4\n")
+	}
+	if _, err = o.IntegrateStrict(f, 2); err == nil {
+		tst.Errorf("strict integration with legendre_1 should have failed\n")
+	}
+	if err = o.WarnIfInsufficient(1); err != nil {
+		tst.Errorf("%v\n", err)
+	}
+
+	// 4-point rule
+	o = NewIntPointsSet(KindQua, "legendre_4")
+	if err = o.WarnIfInsufficient(2); err != nil {
+		tst.Errorf("%v\n", err)
+	}
+	res, err := o.IntegrateStrict(f, 2)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "∫x²", 1e-15, res, 4.0/3.0)
+
+	// other kinds
+	if err = NewIntPointsSet(KindTri, "internal_3").WarnIfInsufficient(3); err == nil {
+		tst.Errorf("internal_3 should be insufficient for degree 3\n")
+	}
+	if err = NewIntPointsSet(KindTet, "internal_4").WarnIfInsufficient(2); err != nil {
+		tst.Errorf("%v\n", err)
+	}
+}
+
+func TestQuadset33(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset33. save and load tables")
+
+	// hex
+	o := NewIntPointsSet(KindHex, "legendre_27")
+	o.SaveTable("/tmp/gosl/gm/quadset33-hex.txt")
+	var p IntPointsSet
+	p.LoadTable("/tmp/gosl/gm/quadset33-hex.txt")
+	chk.String(tst, p.Shape(), "hex")
+	chk.String(tst, p.Name, "legendre_27")
+	chk.String(tst, p.Source, o.Source)
+	chk.String(tst, p.RecommendedFor, o.RecommendedFor)
+	chk.Int(tst, "ndim", p.Ndim, 3)
+	chk.Int(tst, "npts", p.Npts, 27)
+	chk.Deep2(tst, "P", 1e-17, p.P, o.P)
+
+	// table with gosl functions
+	keys, T := io.ReadTable("/tmp/gosl/gm/quadset33-hex.txt")
+	chk.Strings(tst, "keys", keys, []string{"r", "s", "t", "w"})
+	chk.Float64(tst, "Σw", 1e-14, utl.Sum(T["w"]), 8)
+
+	// tri without metadata
+	o = &IntPointsSet{Kind: KindTri, Name: "custom", Ndim: 2, Npts: 1, P: [][]float64{{1.0 / 3.0, 1.0 / 3.0, 0, 0.5}}}
+	o.SaveTable("/tmp/gosl/gm/quadset33-tri.txt")
+	p.LoadTable("/tmp/gosl/gm/quadset33-tri.txt")
+	if !p.Equal(o, 1e-17) || p.Name != "custom" || p.Source != "" {
+		tst.Errorf("tri set was not loaded correctly\n")
+	}
+
+	// errors
+	io.WriteStringToFile("/tmp/gosl/gm/quadset33-wrong.txt", "# shape qua\n# name a\n# ndim 2\n# npts 1\nr w\n0 4\n")
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		p.LoadTable("/tmp/gosl/gm/quadset33-wrong.txt")
+	}()
+}
+
+func TestQuadset34(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset34. draw and compare sets")
+
+	a := NewIntPointsSet(KindQua, "wilson5corner_5")
+	b := NewIntPointsSet(KindQua, "wilson5stable_5")
+	if chk.Verbose {
+		plt.Reset(true, nil)
+		err := IntPointsDrawCompare(a, b, nil, nil, nil)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		plt.Equal()
+		plt.AxisRange(-1.1, 1.1, -1.1, 1.1)
+		plt.HideAllBorders()
+		plt.Gll("r", "s", nil)
+		plt.Save("/tmp/gosl", "quadset34")
+	}
+
+	// errors
+	if err := IntPointsDrawCompare(a, NewIntPointsSet(KindTri, "internal_3"), nil, nil, nil); err == nil {
+		tst.Errorf("qua and tri sets should not be compared\n")
+	}
+	if err := IntPointsDrawCompare(a, NewIntPointsSet(KindHex, "legendre_8"), nil, nil, nil); err == nil {
+		tst.Errorf("sets with different ndim should not be compared\n")
+	}
+}
+
+func TestQuadset35(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadset35. points as matrix and weights as vector")
+
+	o := NewIntPointsSet(KindQua, "legendre_4")
+	X := o.ToMatrix()
+	chk.Int(tst, "m", X.M, 4)
+	chk.Int(tst, "n", X.N, 2)
+	a := 1.0 / math.Sqrt(3.0)
+	chk.Float64(tst, "X00", 1e-15, X.Get(0, 0), -a)
+	chk.Float64(tst, "X10", 1e-15, X.Get(1, 0), +a)
+	chk.Float64(tst, "X31", 1e-15, X.Get(3, 1), +a)
+	chk.Deep2(tst, "X", 1e-15, X.GetDeep2(), [][]float64{{-a, -a}, {+a, -a}, {-a, +a}, {+a, +a}})
+	w := o.WeightsVector()
+	chk.Array(tst, "w", 1e-17, w, []float64{1, 1, 1, 1})
+
+	// copies
+	X.Set(0, 0, 123)
+	w[0] = 123
+	chk.Float64(tst, "P00", 1e-15, o.P[0][0], -a)
+	chk.Float64(tst, "P03", 1e-15, o.P[0][3], 1)
+
+	// tri
+	o = NewIntPointsSet(KindTri, "internal_3")
+	X, w = o.ToMatrix(), o.WeightsVector()
+	chk.Int(tst, "tri: m", X.M, 3)
+	chk.Int(tst, "tri: n", X.N, 2)
+	chk.Float64(tst, "tri: Σw", 1e-15, w.Accum(), 0.5)
+}
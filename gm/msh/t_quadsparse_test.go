@@ -0,0 +1,77 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package msh
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/utl"
+)
+
+func TestQuadsparse01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadsparse01. Smolyak sparse grids")
+
+	// ∫x^k dx over [-1,1]
+	integ1d := func(k int) float64 {
+		if k%2 == 1 {
+			return 0
+		}
+		return 2.0 / float64(k+1)
+	}
+
+	// 1D: Clenshaw-Curtis rules
+	X, W := QuadPointsSmolyak(1, 1)
+	chk.Int(tst, "npts", len(X), 3)
+	chk.Array(tst, "W", 1e-15, W, []float64{1.0 / 3.0, 4.0 / 3.0, 1.0 / 3.0})
+
+	// 4D: monomials up to total degree 2⋅level+1
+	ndim := 4
+	for level := 0; level <= 3; level++ {
+		X, W = QuadPointsSmolyak(ndim, level)
+		nfull := int(math.Pow(float64(quadPointsClenshawCurtisM(level+1)), float64(ndim)))
+		io.Pfblue2("\nlevel = %d: npts = %d (tensor-product = %d)\n", level, len(X), nfull)
+		if level > 1 && len(X) >= nfull/10 {
+			tst.Errorf("sparse grid has too many points: %d\n", len(X))
+			return
+		}
+		chk.Float64(tst, "sum(w)", 1e-13, utl.Sum(W), 16)
+		deg := 2*level + 1
+		e := make([]int, ndim)
+		var loop func(d, rem int)
+		loop = func(d, rem int) {
+			if d == ndim {
+				res, ana := 0.0, 1.0
+				for i, x := range X {
+					f := W[i]
+					for k := 0; k < ndim; k++ {
+						f *= math.Pow(x[k], float64(e[k]))
+					}
+					res += f
+				}
+				for k := 0; k < ndim; k++ {
+					ana *= integ1d(e[k])
+				}
+				chk.AnaNum(tst, io.Sf("∫x^%v", e), 1e-13, ana, res, false)
+				return
+			}
+			for e[d] = 0; e[d] <= rem; e[d]++ {
+				loop(d+1, rem-e[d])
+			}
+		}
+		loop(0, deg)
+	}
+	chk.Int(tst, "npts(4,3)", len(X), 137)
+
+	// invalid input
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		QuadPointsSmolyak(0, 1)
+	}()
+}
@@ -0,0 +1,800 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package msh
+
+import (
+	"math"
+	"math/cmplx"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/utl"
+)
+
+func TestQuadtools01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadtools01. integration using quadrature points")
+
+	// integrand and Jacobian
+	fcn := func(x la.Vector) (f float64) {
+		f = x[0]*x[0] + x[1]*x[1]
+		return
+	}
+	detJ := func(x la.Vector) (J float64) {
+		J = 2.0 + x[0]
+		return
+	}
+
+	// integrate over reference square
+	for _, name := range []string{"legendre_4", "legendre_9", "legendre_16", "wilson5corner_5", "wilson8default_8"} {
+		pts := GetIntPoints()[KindQua][name]
+		res := QuadPointsIntegrate(pts, 2, fcn)
+		io.Pforan("%-16s: res = %v\n", name, res)
+		chk.Float64(tst, io.Sf("%s: ∫(x²+y²)dxdy", name), 1e-14, res, 8.0/3.0)
+		res = QuadPointsIntegrateJ(pts, 2, fcn, detJ)
+		chk.Float64(tst, io.Sf("%s: ∫(x²+y²)(2+x)dxdy", name), 1e-15, res, 16.0/3.0)
+	}
+}
+
+func TestQuadtools02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadtools02. sum of weights")
+
+	// check all sets in database
+	for kind, db := range GetIntPoints() {
+		measure, err := ReferenceMeasure(kind)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		for name, pts := range db {
+			if kind == KindTet && name == "internal_6" {
+				continue // NOTE: this set is not defined over the reference tetrahedron
+			}
+			sum := QuadPointsSumWeights(pts)
+			io.Pf("kind = %d, name = %-16s: sum = %v\n", kind, name, sum)
+			err = QuadPointsCheckWeights(pts, measure, 1e-14)
+			if err != nil {
+				tst.Errorf("kind=%d, name=%q: %v\n", kind, name, err)
+			}
+		}
+	}
+
+	// parameterised sets
+	err := QuadPointsCheckWeights(QuadPointsWilson5(1.5, false), 4, 1e-15)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+	}
+	err = QuadPointsCheckWeights(QuadPointsWilson8(0.5), 4, 1e-15)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+	}
+
+	// wrong sum
+	err = QuadPointsCheckWeights([][]float64{{0, 0, 0, 1}, {0.5, 0, 0, 0.5}}, 2, 1e-15)
+	if err == nil {
+		tst.Errorf("CheckWeights should have failed\n")
+	}
+}
+
+func TestQuadtools03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadtools03. negative weights")
+
+	// sets with negative weights
+	negative := map[int][]string{
+		KindTri: {"internal_4", "internal_13"},
+		KindTet: {"internal_5", "internal_11"},
+	}
+
+	// check all sets in database
+	for kind, db := range GetIntPoints() {
+		for name := range db {
+			expected := utl.StrIndexSmall(negative[kind], name) >= 0
+			o := NewIntPointsSet(kind, name)
+			io.Pf("kind = %d, name = %-16s: negative = %v\n", kind, name, o.HasNegativeWeights())
+			if o.HasNegativeWeights() != expected {
+				tst.Errorf("kind=%d, name=%q: HasNegativeWeights should be %v\n", kind, name, expected)
+			}
+		}
+	}
+
+	// Gauss rules
+	for _, rule := range []string{"legendre", "lobatto", "radauleft", "radauright"} {
+		if QuadPointsHasNegativeWeights(QuadPointsGaussTensor(rule, []int{5, 4, 3})) {
+			tst.Errorf("%s rule should not have negative weights\n", rule)
+		}
+	}
+}
+
+func TestQuadtools04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadtools04. adaptive integration")
+
+	// Gaussian bump and its integral over the reference square
+	σ, μx, μy := 0.05, 0.3, -0.2
+	fcn := func(x la.Vector) float64 {
+		dx, dy := x[0]-μx, x[1]-μy
+		return math.Exp(-(dx*dx + dy*dy) / (2.0 * σ * σ))
+	}
+	factor := func(μ float64) float64 {
+		return (math.Erf((1.0-μ)/(σ*math.Sqrt2)) + math.Erf((1.0+μ)/(σ*math.Sqrt2))) / 2.0
+	}
+	ana := 2.0 * math.Pi * σ * σ * factor(μx) * factor(μy)
+
+	// adaptive integration
+	res, err := QuadPointsIntegrateAdaptive(fcn, KindQua, 1e-9, 20)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("res = %v, error = %v\n", res, math.Abs(res-ana))
+	chk.Float64(tst, "∫bump", 1e-8, res, ana)
+
+	// fixed rule for comparison
+	res = QuadPointsIntegrate(GetIntPoints()[KindQua]["legendre_16"], 2, fcn)
+	io.Pforan("legendre_16: error = %v\n", math.Abs(res-ana))
+
+	// 1D and 3D
+	res, err = QuadPointsIntegrateAdaptive(func(x la.Vector) float64 { return fcn(la.Vector{x[0], μy}) }, KindLin, 1e-10, 20)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "∫bump(x)", 1e-9, res, σ*math.Sqrt(2.0*math.Pi)*factor(μx))
+	res, err = QuadPointsIntegrateAdaptive(func(x la.Vector) float64 { return x[0] * x[0] * x[1] * x[1] * x[2] * x[2] }, KindHex, 1e-12, 5)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "∫x²y²z²", 1e-14, res, 8.0/27.0)
+
+	// not enough subdivisions
+	_, err = QuadPointsIntegrateAdaptive(fcn, KindQua, 1e-9, 2)
+	if err == nil {
+		tst.Errorf("adaptive integration should have failed\n")
+		return
+	}
+	io.Pf("OK, caught the following error: %v", err)
+
+	// invalid cell kind
+	_, err = QuadPointsIntegrateAdaptive(fcn, KindTri, 1e-9, 2)
+	if err == nil {
+		tst.Errorf("adaptive integration should have failed\n")
+	}
+}
+
+func TestQuadtools05(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadtools05. concurrent integration")
+
+	// slow integrand
+	fcn := func(x la.Vector) float64 {
+		time.Sleep(100 * time.Microsecond)
+		return math.Exp(x[0]) * math.Sin(3*x[1]+x[2]) / (1.1 + x[2])
+	}
+
+	// compare with serial integration
+	pts := QuadPointsGaussLegendre(3, 125)
+	ref := QuadPointsIntegrate(pts, 3, fcn)
+	for _, nWorkers := range []int{1, 2, 3, 8, 200} {
+		res := QuadPointsIntegratePar(pts, 3, fcn, nWorkers)
+		io.Pforan("nWorkers = %3d: res = %v\n", nWorkers, res)
+		if res != ref {
+			tst.Errorf("concurrent result %v is different than serial result %v\n", res, ref)
+			return
+		}
+	}
+
+	// invalid number of workers
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		QuadPointsIntegratePar(pts, 3, fcn, 0)
+	}()
+}
+
+func TestQuadtools06(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadtools06. match sets of points")
+
+	a := GetIntPoints()[KindQua]["legendre_4"]
+	b := [][]float64{a[3], a[1], a[2], a[0]}
+	if !QuadPointsMatch(a, b, 1e-15) {
+		tst.Errorf("sets should match\n")
+	}
+	if QuadPointsMatch(a, b[:3], 1e-15) {
+		tst.Errorf("sets with different sizes should not match\n")
+	}
+	c := [][]float64{a[0], a[0], a[1], a[2]}
+	if QuadPointsMatch(a, c, 1e-15) || QuadPointsMatch(c, a, 1e-15) {
+		tst.Errorf("sets with repeated points should not match\n")
+	}
+}
+
+func TestQuadtools07(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadtools07. points in reference cell")
+
+	// single points
+	for _, c := range []struct {
+		kind int
+		x    []float64
+		in   bool
+	}{
+		{KindLin, []float64{1}, true},
+		{KindLin, []float64{1.1}, false},
+		{KindQua, []float64{-1, 1}, true},
+		{KindQua, []float64{0, 1.1}, false},
+		{KindHex, []float64{0.5, 0.5, -1.1}, false},
+		{KindTri, []float64{0.5, 0.5}, true},
+		{KindTri, []float64{-0.1, 0.5}, false},
+		{KindTet, []float64{0.2, 0.3, 0.4}, true},
+		{KindTet, []float64{0.3, 0.3, 0.5}, false},
+		{KindWed, []float64{0.2, 0.2, -1}, true},
+		{KindPyr, []float64{0.5, -0.5, 0.5}, true},
+		{KindPyr, []float64{0.6, 0, 0.5}, false},
+	} {
+		if InReferenceCell(c.kind, c.x, 1e-15) != c.in {
+			tst.Errorf("kind=%d, x=%v: InReferenceCell should be %v\n", c.kind, c.x, c.in)
+		}
+	}
+
+	// sets in database
+	for _, name := range []string{"legendre_4", "legendre_9", "legendre_16", "wilson5corner_5", "wilson8default_8"} {
+		if !NewIntPointsSet(KindQua, name).AllInReferenceCell(1e-15) {
+			tst.Errorf("points of %q should be in reference cell\n", name)
+		}
+	}
+	if NewIntPointsSet(KindTet, "internal_6").AllInReferenceCell(1e-15) {
+		tst.Errorf("points of tet:internal_6 should not be in reference cell\n")
+	}
+}
+
+func TestQuadtools08(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadtools08. integration of vector function")
+
+	// f(x) = {x², exp(x)} over the reference segment
+	pts := GetIntPoints()[KindLin]["legendre_10"]
+	res := QuadPointsIntegrateVector(pts, 1, 2, func(fx, x la.Vector) {
+		fx[0] = x[0] * x[0]
+		fx[1] = math.Exp(x[0])
+	})
+	chk.Array(tst, "∫{x²,exp(x)}", 1e-14, res, []float64{2.0 / 3.0, math.E - 1.0/math.E})
+
+	// linear shape functions of 2-node segment: ∫N dx = {1, 1}
+	res = QuadPointsIntegrateVector(pts, 1, 2, func(N, x la.Vector) {
+		N[0] = (1 - x[0]) / 2
+		N[1] = (1 + x[0]) / 2
+	})
+	chk.Array(tst, "∫N", 1e-14, res, []float64{1, 1})
+
+	// invalid length
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		QuadPointsIntegrateVector(pts, 1, 0, func(fx, x la.Vector) {})
+	}()
+}
+
+func TestQuadtools09(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadtools09. integration of matrix function")
+
+	// mass matrix of qua4: M_ij = ∫N_i⋅N_j dr ds
+	S := la.NewVector(4)
+	M := QuadPointsIntegrateMatrix(GetIntPoints()[KindQua]["legendre_4"], 2, 4, func(F *la.Matrix, x la.Vector) {
+		FuncQua4(S, nil, x, false)
+		for i := 0; i < 4; i++ {
+			for j := 0; j < 4; j++ {
+				F.Set(i, j, S[i]*S[j])
+			}
+		}
+	})
+	io.Pforan("M =\n%v\n", M.Print("%10.6f"))
+	chk.Deep2(tst, "M", 1e-15, M.GetDeep2(), [][]float64{
+		{4.0 / 9.0, 2.0 / 9.0, 1.0 / 9.0, 2.0 / 9.0},
+		{2.0 / 9.0, 4.0 / 9.0, 2.0 / 9.0, 1.0 / 9.0},
+		{1.0 / 9.0, 2.0 / 9.0, 4.0 / 9.0, 2.0 / 9.0},
+		{2.0 / 9.0, 1.0 / 9.0, 2.0 / 9.0, 4.0 / 9.0},
+	})
+
+	// invalid size
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		QuadPointsIntegrateMatrix(GetIntPoints()[KindQua]["legendre_4"], 2, 0, func(F *la.Matrix, x la.Vector) {})
+	}()
+}
+
+func TestQuadtools10(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadtools10. symmetry of sets")
+
+	// all sets in database, except the "internal_6" set for tets (see IntPointsFindDegree)
+	for cellKind := 0; cellKind < KindNumMax; cellKind++ {
+		for name, P := range GetIntPoints()[cellKind] {
+			sym := QuadPointsCheckSymmetry(P, cellKind, 1e-14)
+			io.Pf("kind = %d %-18s symmetric = %v\n", cellKind, name, sym)
+			if sym != (cellKind != KindTet || name != "internal_6") {
+				tst.Errorf("symmetry check failed for set %q of cellKind = %d\n", name, cellKind)
+			}
+		}
+	}
+
+	// generated sets
+	if !QuadPointsCheckSymmetry(QuadPointsWilson8(0.5), KindQua, 1e-15) {
+		tst.Errorf("symmetry check failed: wilson8(0.5)\n")
+	}
+	if !QuadPointsCheckSymmetry(QuadPointsGaussLegendre(2, 9), KindQua, 1e-15) {
+		tst.Errorf("symmetry check failed: legendre_9\n")
+	}
+	if QuadPointsCheckSymmetry(QuadPointsGaussTensor("legendre", []int{2, 3}), KindQua, 1e-15) {
+		tst.Errorf("symmetry check failed: legendre 2x3\n")
+	}
+	if QuadPointsCheckSymmetry(QuadPointsGaussRadau(1, 3, false), KindLin, 1e-15) {
+		tst.Errorf("symmetry check failed: radau\n")
+	}
+
+	// perturbed set
+	o := NewIntPointsSet(KindTri, "internal_7").Clone()
+	if !o.CheckSymmetry(1e-15) {
+		tst.Errorf("symmetry check failed: tri internal_7\n")
+	}
+	o.P[3][0] += 1e-10
+	if o.CheckSymmetry(1e-15) {
+		tst.Errorf("symmetry check failed: tri internal_7 (perturbed)\n")
+	}
+	if !o.CheckSymmetry(1e-9) {
+		tst.Errorf("symmetry check failed: tri internal_7 (perturbed; tol=1e-9)\n")
+	}
+}
+
+func TestQuadtools11(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadtools11. p-adaptive integration")
+
+	// exp(x) over reference line
+	fcn := func(x la.Vector) float64 { return math.Exp(x[0]) }
+	res, npts, err := QuadPointsIntegratePAdaptive(fcn, KindLin, 1e-12, 20)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("res = %v, npts = %d\n", res, npts)
+	chk.Float64(tst, "∫exp(x)", 1e-14, res, math.E-1.0/math.E)
+	chk.Int(tst, "npts", npts, 8)
+
+	// exp(x+y) over reference square
+	fcn = func(x la.Vector) float64 { return math.Exp(x[0] + x[1]) }
+	res, npts, err = QuadPointsIntegratePAdaptive(fcn, KindQua, 1e-10, 100)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("res = %v, npts = %d\n", res, npts)
+	chk.Float64(tst, "∫exp(x+y)", 1e-13, res, math.Pow(math.E-1.0/math.E, 2))
+
+	// polynomial over reference triangle
+	fcn = func(x la.Vector) float64 { return x[0] * x[0] * x[1] }
+	res, _, err = QuadPointsIntegratePAdaptive(fcn, KindTri, 1e-14, 100)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "∫x²y", 1e-15, res, 1.0/60.0)
+
+	// too few points
+	_, npts, err = QuadPointsIntegratePAdaptive(func(x la.Vector) float64 { return math.Abs(x[0]) }, KindLin, 1e-12, 10)
+	if err == nil {
+		tst.Errorf("integration should have failed\n")
+		return
+	}
+	chk.Int(tst, "npts", npts, 10)
+	io.Pf("OK, caught the following error: %v", err)
+
+	// no rules available
+	_, _, err = QuadPointsIntegratePAdaptive(func(x la.Vector) float64 { return math.Sqrt(x[0]) }, KindTri, 1e-14, 100)
+	if err == nil {
+		tst.Errorf("integration should have failed\n")
+		return
+	}
+	io.Pf("OK, caught the following error: %v", err)
+}
+
+func TestQuadtools12(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadtools12. integration of complex functions")
+
+	// ∫ exp(i⋅k⋅x) dx over [-1,1] = 2⋅sin(k)/k
+	pts := QuadPointsGaussLegendre(1, 10)
+	for _, k := range []float64{0.5, 1, 2, 3} {
+		res := QuadPointsIntegrateComplex(pts, 1, func(x la.Vector) complex128 {
+			return cmplx.Exp(complex(0, k*x[0]))
+		})
+		ana := complex(2*math.Sin(k)/k, 0)
+		io.Pforan("k = %v: res = %v, ana = %v\n", k, res, ana)
+		chk.Complex128(tst, io.Sf("k = %v", k), 1e-13, res, ana)
+	}
+
+	// ∫ (x + i⋅y)² dxdy over [-1,1]² = 8/3 - 8/3 = 0 and ∫ (x + i⋅y)⋅(x - i⋅y) = 8/3
+	pts = QuadPointsGaussLegendre(2, 4)
+	res := QuadPointsIntegrateComplex(pts, 2, func(x la.Vector) complex128 {
+		z := complex(x[0], x[1])
+		return z * z
+	})
+	chk.Complex128(tst, "∫z²", 1e-15, res, 0)
+	res = QuadPointsIntegrateComplex(pts, 2, func(x la.Vector) complex128 {
+		z := complex(x[0], x[1])
+		return z * cmplx.Conj(z)
+	})
+	chk.Complex128(tst, "∫z⋅z̄", 1e-14, res, 8.0/3.0)
+}
+
+func TestQuadtools13(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadtools13. bounds and centroid")
+
+	// reference qua
+	a := 0.7745966692414834
+	o := NewIntPointsSet(KindQua, "legendre_9")
+	xmin, xmax := o.Bounds()
+	chk.Array(tst, "xmin", 1e-15, xmin, []float64{-a, -a})
+	chk.Array(tst, "xmax", 1e-15, xmax, []float64{+a, +a})
+	chk.Array(tst, "centroid", 1e-15, o.Centroid(), []float64{0, 0})
+
+	// reference tri
+	o = NewIntPointsSet(KindTri, "internal_7")
+	chk.Array(tst, "tri: centroid", 1e-15, o.Centroid(), []float64{1.0 / 3.0, 1.0 / 3.0})
+
+	// mapped points
+	pts := QuadPointsGaussLegendreAB(2, 6, 3)
+	xmin, xmax = QuadPointsBounds(pts, 1)
+	chk.Array(tst, "xmin", 1e-15, xmin, []float64{4 - 2*a})
+	chk.Array(tst, "xmax", 1e-15, xmax, []float64{4 + 2*a})
+	chk.Array(tst, "centroid", 1e-15, QuadPointsCentroid(pts, 1), []float64{4})
+
+	// non-symmetric set
+	pts = [][]float64{{-1, 2, 0, 1}, {3, 0, 0, 3}}
+	xmin, xmax = QuadPointsBounds(pts, 2)
+	chk.Array(tst, "xmin", 1e-15, xmin, []float64{-1, 0})
+	chk.Array(tst, "xmax", 1e-15, xmax, []float64{3, 2})
+	chk.Array(tst, "centroid", 1e-15, QuadPointsCentroid(pts, 2), []float64{2, 0.5})
+}
+
+func TestQuadtools14(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadtools14. integration with cached Jacobian")
+
+	// mapping: x = r⋅(2 + s), y = s  ⇒  J = 2 + s
+	pts := QuadPointsGaussLegendre(2, 9)
+	detJ := func(r la.Vector) float64 { return 2 + r[1] }
+	J := make([]float64, len(pts))
+	for i, p := range pts {
+		J[i] = detJ(p[:2])
+	}
+	o := NewIntPointsCachedJ(pts, J)
+	chk.Int(tst, "npts", o.Npts, 9)
+
+	// several "timesteps" with different integrands
+	fvals := make([]float64, len(pts))
+	for step := 0; step < 3; step++ {
+		a := float64(step + 1)
+		f := func(r la.Vector) float64 { return a*r[0]*r[0] + r[1] }
+		for i, p := range pts {
+			fvals[i] = f(p[:2])
+		}
+		res := o.Integrate(fvals)
+		chk.Float64(tst, io.Sf("step %d", step), 1e-15, res, QuadPointsIntegrateJ(pts, 2, f, detJ))
+		chk.Float64(tst, io.Sf("step %d: ana", step), 1e-14, res, 8.0*a/3.0+4.0/3.0)
+	}
+
+	// wrong lengths
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		NewIntPointsCachedJ(pts, J[:3])
+	}()
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		o.Integrate(fvals[:3])
+	}()
+}
+
+func TestQuadtools15(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadtools15. collapse duplicates")
+
+	pts := [][]float64{
+		{-0.5, 0, 0, 0.25},
+		{+0.5, 0, 0, 1.0},
+		{-0.5 + 1e-16, 0, 0, 0.75},
+		{+0.5, 0, 0, 0.0},
+	}
+	res := QuadPointsCollapseDuplicates(pts, 1e-15)
+	chk.Deep2(tst, "res", 1e-15, res, [][]float64{
+		{-0.5, 0, 0, 1.0},
+		{+0.5, 0, 0, 1.0},
+	})
+	chk.Float64(tst, "pts[0][3] (unchanged)", 1e-17, pts[0][3], 0.25)
+
+	// composite rule of Newton-Cotes sets with shared points
+	o := &IntPointsSet{Kind: KindQua, Name: "simpson2x2", Ndim: 2}
+	for _, xc := range []float64{-0.5, 0.5} {
+		for _, yc := range []float64{-0.5, 0.5} {
+			for _, p := range QuadPointsNewtonCotes(2, 9) {
+				o.P = append(o.P, []float64{xc + p[0]/2, yc + p[1]/2, 0, p[3] / 4})
+			}
+		}
+	}
+	o.Npts = len(o.P)
+	c := o.CollapseDuplicates(1e-15)
+	chk.Int(tst, "npts (before)", o.Npts, 36)
+	chk.Int(tst, "npts (after)", c.Npts, 25)
+	chk.Float64(tst, "sum of weights", 1e-15, QuadPointsSumWeights(c.P), 4)
+	chk.Float64(tst, "∫x²y²", 1e-15, QuadPointsIntegrate(c.P, 2, func(x la.Vector) float64 {
+		return x[0] * x[0] * x[1] * x[1]
+	}), 4.0/9.0)
+	if c.Validate(1e-15) != nil {
+		tst.Errorf("collapsed set should be valid\n")
+	}
+}
+
+func TestQuadtools16(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadtools16. sort by weight")
+
+	o := NewIntPointsSet(KindQua, "legendre_9")
+	d := o.SortByWeight(true)
+	chk.Array(tst, "P[0]", 1e-15, d.P[0], []float64{0, 0, 0, 64.0 / 81.0})
+	for i := 1; i < d.Npts; i++ {
+		if d.P[i][3] > d.P[i-1][3] {
+			tst.Errorf("weights must be in descending order\n")
+			return
+		}
+	}
+	if !d.Equal(o, 1e-17) {
+		tst.Errorf("sorted set must have the same points\n")
+	}
+	chk.Float64(tst, "o.P[4] (unchanged)", 1e-17, o.P[4][3], 64.0/81.0)
+
+	// ascending; stable
+	a := o.SortByWeight(false)
+	a5 := 25.0 / 81.0
+	chk.Deep2(tst, "P[:4]", 1e-15, a.P[:4], [][]float64{
+		{-0.7745966692414834, -0.7745966692414834, 0, a5},
+		{+0.7745966692414834, -0.7745966692414834, 0, a5},
+		{-0.7745966692414834, +0.7745966692414834, 0, a5},
+		{+0.7745966692414834, +0.7745966692414834, 0, a5},
+	})
+	chk.Array(tst, "P[8]", 1e-15, a.P[8], []float64{0, 0, 0, 64.0 / 81.0})
+}
+
+func TestQuadtools17(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadtools17. accumulator")
+
+	pts := GetIntPoints()[KindQua]["legendre_4"]
+	f := func(x la.Vector) float64 { return 1 + x[0]*x[0] + x[0]*x[1]*x[1] }
+	o := NewIntPointsAccumulator(pts)
+
+	// incomplete
+	o.Add(f(pts[0][:2]))
+	chk.Int(tst, "count", o.Count(), 1)
+	_, err := o.Result()
+	if err == nil {
+		tst.Errorf("Result should have failed\n")
+		return
+	}
+	io.Pf("OK, caught the following error: %v", err)
+
+	// stream values
+	for _, p := range pts[1:] {
+		o.Add(f(p[:2]))
+	}
+	res, err := o.Result()
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "res", 1e-15, res, QuadPointsIntegrate(pts, 2, f))
+	chk.Float64(tst, "res (ana)", 1e-15, res, 4.0+4.0/3.0)
+
+	// too many values
+	o.Add(1)
+	_, err = o.Result()
+	if err == nil {
+		tst.Errorf("Result should have failed\n")
+		return
+	}
+	io.Pf("OK, caught the following error: %v", err)
+
+	// reset
+	o.Reset()
+	for range pts {
+		o.Add(1)
+	}
+	res, err = o.Result()
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "∫1", 1e-15, res, 4)
+}
+
+func TestQuadtools18(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadtools18. check exactness")
+
+	// exact sets
+	for _, c := range []struct {
+		kind    int
+		setName string
+		degree  int
+	}{
+		{KindLin, "legendre_3", 5},
+		{KindTri, "internal_3", 2},
+		{KindQua, "legendre_9", 5},
+		{KindTet, "internal_4", 2},
+		{KindHex, "legendre_8", 3},
+	} {
+		o := NewIntPointsSet(c.kind, c.setName)
+		err := o.CheckExactness(c.degree, 1e-12)
+		if err != nil {
+			tst.Errorf("%s: %v\n", c.setName, err)
+		}
+	}
+
+	// under-integrating set
+	pts := NewIntPointsSet(KindQua, "legendre_4").P
+	err := QuadPointsCheckExactness(pts, KindQua, 4, 1e-12)
+	if err == nil {
+		tst.Errorf("legendre_4 should not integrate degree 4 exactly\n")
+		return
+	}
+	io.Pforan("%v", err)
+	if !strings.Contains(err.Error(), "r^0⋅s^4⋅t^0") {
+		tst.Errorf("error should name the monomial s^4: %v\n", err)
+	}
+
+	// wrong weights
+	pts = NewIntPointsSet(KindTri, "internal_3").Clone().ScaleWeights(1.01).P
+	err = QuadPointsCheckExactness(pts, KindTri, 0, 1e-12)
+	if err == nil || !strings.Contains(err.Error(), "r^0⋅s^0⋅t^0") {
+		tst.Errorf("constant function should have failed: %v\n", err)
+	}
+}
+
+func TestQuadtools19(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadtools19. integrate precomputed values")
+
+	o := NewIntPointsSet(KindQua, "legendre_9")
+	f := func(x la.Vector) float64 { return x[0]*x[0]*x[1]*x[1] + x[0] }
+	X, _ := o.Coords()
+	values := make([]float64, o.Npts)
+	for i, x := range X {
+		values[i] = f(x)
+	}
+	chk.Float64(tst, "∫f", 1e-15, o.IntegrateValues(values), QuadPointsIntegrate(o.P, 2, f))
+	chk.Float64(tst, "∫f: ana", 1e-15, o.IntegrateValues(values), 4.0/9.0)
+
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		o.IntegrateValues(values[:3])
+	}()
+}
+
+func TestQuadtools20(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadtools20. barycentric coordinates")
+
+	// tri
+	L := NewIntPointsSet(KindTri, "internal_3").Barycentric()
+	chk.Deep2(tst, "tri: L", 1e-15, L, [][]float64{
+		{1.0 / 6.0, 1.0 / 6.0, 2.0 / 3.0},
+		{2.0 / 3.0, 1.0 / 6.0, 1.0 / 6.0},
+		{1.0 / 6.0, 2.0 / 3.0, 1.0 / 6.0},
+	})
+
+	// tet
+	L = NewIntPointsSet(KindTet, "internal_4").Barycentric()
+	chk.Int(tst, "tet: len(L)", len(L), 4)
+	for i, l := range L {
+		chk.Int(tst, "tet: len(L[i])", len(l), 4)
+		chk.Float64(tst, io.Sf("tet: %d: Σλ", i), 1e-15, l[0]+l[1]+l[2]+l[3], 1)
+	}
+
+	// all simplex sets
+	for _, kind := range []int{KindTri, KindTet} {
+		for name, P := range GetIntPoints()[kind] {
+			for i, l := range QuadPointsToBarycentric(P, kind) {
+				sum := 0.0
+				for _, λ := range l {
+					sum += λ
+				}
+				chk.Float64(tst, io.Sf("kind %d: %s: %d: Σλ", kind, name, i), 1e-15, sum, 1)
+			}
+		}
+	}
+
+	// errors
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		NewIntPointsSet(KindQua, "legendre_4").Barycentric()
+	}()
+}
+
+func TestQuadtools21(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadtools21. integration over curved cells")
+
+	// quarter of annulus with 1 ≤ ρ ≤ 2
+	annulus := func(ref []float64) []float64 {
+		ρ, θ := 1.5+0.5*ref[0], math.Pi/4.0*(1.0+ref[1])
+		return []float64{ρ * math.Cos(θ), ρ * math.Sin(θ)}
+	}
+	one := func(x []float64) float64 { return 1 }
+	ρ2 := func(x []float64) float64 { return x[0]*x[0] + x[1]*x[1] }
+	o := NewIntPointsSet(KindQua, "legendre_9")
+	chk.Float64(tst, "area", 1e-9, o.IntegrateCurved(one, annulus, 1e-5), 3.0*math.Pi/4.0)
+	chk.Float64(tst, "∫ρ²", 1e-9, o.IntegrateCurved(ρ2, annulus, 1e-5), 15.0*math.Pi/8.0)
+
+	// 1D and 3D (box with sides 2, 3 and 4)
+	line := func(ref []float64) []float64 { return []float64{2 + 3*ref[0]} } // x ∈ [-1,5]
+	chk.Float64(tst, "∫x dx", 1e-9, QuadPointsIntegrateCurved(GetIntPoints()[KindLin]["legendre_3"], 1, func(x []float64) float64 { return x[0] }, line, 1e-5), 12.0)
+	box := func(ref []float64) []float64 { return []float64{1 + ref[0], 1.5 * ref[1], 2 * ref[2]} }
+	chk.Float64(tst, "volume", 1e-9, QuadPointsIntegrateCurved(GetIntPoints()[KindHex]["legendre_8"], 3, one, box, 1e-5), 24)
+
+	// identity map returning its argument
+	identity := func(ref []float64) []float64 { return ref }
+	chk.Float64(tst, "identity: area", 1e-9, o.IntegrateCurved(one, identity, 1e-5), 4)
+	chk.Float64(tst, "identity: ∫x²", 1e-9, o.IntegrateCurved(func(x []float64) float64 { return x[0] * x[0] }, identity, 1e-5), 4.0/3.0)
+
+	// in-place map modifying its argument and map reusing an internal buffer
+	inPlace := func(ref []float64) []float64 {
+		ref[0], ref[1] = 2*ref[0], 3*ref[1]
+		return ref
+	}
+	chk.Float64(tst, "in-place: area", 1e-9, o.IntegrateCurved(one, inPlace, 1e-5), 24)
+	buffer := make([]float64, 2)
+	reused := func(ref []float64) []float64 {
+		buffer[0], buffer[1] = 2*ref[0], 3*ref[1]
+		return buffer
+	}
+	chk.Float64(tst, "buffer: area", 1e-9, o.IntegrateCurved(one, reused, 1e-5), 24)
+
+	// errors
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		o.IntegrateCurved(one, annulus, 0)
+	}()
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		o.IntegrateCurved(one, func(ref []float64) []float64 { return []float64{ref[0], ref[0]} }, 1e-5)
+	}()
+}
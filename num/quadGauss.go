@@ -46,22 +46,39 @@ func QuadGaussL10(a, b float64, f fun.Ss) (res float64) {
 //     x1 -- lower limit of integration
 //     x2 -- upper limit of integration
 //     n  -- number of points for quadrature formula
+//   Note: the initial guesses for Newton's method are given by Tricomi's asymptotic approximation;
+//         a panic occurs if n < 1 or Newton's method does not converge. See GaussLegendreXWerr
 //   Reference:
 //   [1] Press WH, Teukolsky SA, Vetterling WT, Fnannery BP (2007) Numerical Recipes: The Art of
 //       Scientific Computing. Third Edition. Cambridge University Press. 1235p.
 func GaussLegendreXW(x1, x2 float64, n int) (x, w []float64) {
+	x, w, err := GaussLegendreXWerr(x1, x2, n)
+	if err != nil {
+		chk.Panic("%v", err)
+	}
+	return
+}
+
+// GaussLegendreXWerr computes positions (xi) and weights (wi) to perform Gauss-Legendre integrations
+// and returns an error if n < 1 or Newton's method does not converge. See GaussLegendreXW
+func GaussLegendreXWerr(x1, x2 float64, n int) (x, w []float64, err error) {
+	if n < 1 {
+		return nil, nil, chk.Err("number of points for Gauss-Legendre quadrature must be at least 1. n=%d is invalid\n", n)
+	}
 	x = make([]float64, n)
 	w = make([]float64, n)
 	EPS := MACHEPS // relative precision.
+	N := float64(n)
 	var z1, z, xm, xl, pp, p3, p2, p1 float64
 	m := (n + 1) / 2 // The roots are symmetric in the interval, so we only have to find half of them.
 	xm = 0.5 * (x2 + x1)
 	xl = 0.5 * (x2 - x1)
 	for i := 0; i < m; i++ { // Loop over the desired roots.
-		z = math.Cos(3.141592654 * (float64(i) + 0.75) / (float64(n) + 0.5))
+		// Tricomi's asymptotic approximation to the ith root is the initial guess
+		z = (1.0 - 1.0/(8.0*N*N) + 1.0/(8.0*N*N*N)) * math.Cos(math.Pi*(4.0*float64(i)+3.0)/(4.0*N+2.0))
 		// starting with this approximation to the ith root, we enter the main loop of refinement by Newton's method.
-		it, MAXIT := 0, 10
-		for it := 0; it < MAXIT; it++ {
+		it, MAXIT, converged := 0, 100, false
+		for it = 0; it < MAXIT; it++ {
 			p1 = 1.0
 			p2 = 0.0
 			for j := 0; j < n; j++ { // Loop up the recurrence relation to get the Legendre polynomial evaluated at z.
@@ -70,15 +87,16 @@ func GaussLegendreXW(x1, x2 float64, n int) (x, w []float64) {
 				p1 = ((2.0*float64(j)+1.0)*z*p2 - float64(j)*p3) / (float64(j) + 1.0)
 			}
 			// p1 is now the desired Legendre polynomial. We next compute pp, its derivative, by a standard relation involving also p2, the polynomial of one lower order.
-			pp = float64(n) * (z*p1 - p2) / (z*z - 1.0)
+			pp = N * (z*p1 - p2) / (z*z - 1.0)
 			z1 = z
 			z = z1 - p1/pp // Newton's method.
-			if math.Abs(z-z1) < EPS {
+			if converged { // one more step after convergence reduces the error to round-off
 				break
 			}
+			converged = math.Abs(z-z1) <= EPS
 		}
 		if it == MAXIT {
-			chk.Panic("Newton's method did not converge after %d iterations", it)
+			return nil, nil, chk.Err("Newton's method did not converge after %d iterations. n=%d\n", it, n)
 		}
 		x[i] = xm - xl*z // Scale the root to the desired interval, and put in its symmetric counterpart.
 		x[n-1-i] = xm + xl*z
@@ -88,6 +106,307 @@ func GaussLegendreXW(x1, x2 float64, n int) (x, w []float64) {
 	return
 }
 
+// GaussLobattoXW computes positions (xi) and weights (wi) to perform Gauss-Lobatto-Legendre integrations.
+// The end points x1 and x2 are included in the set of positions; the other positions are the roots
+// of P'_{n-1}(x), where P_{n-1} is the Legendre polynomial of degree n-1.
+//   Input:
+//     x1 -- lower limit of integration
+//     x2 -- upper limit of integration
+//     n  -- number of points for quadrature formula. n ≥ 2
+//   Note: the quadrature is exact for polynomials of degree up to 2n-3
+//   Reference:
+//   [1] Canuto C, Hussaini MY, Quarteroni A, Zang TA (2006) Spectral Methods: Fundamentals in
+//       Single Domains. Springer. 563p
+func GaussLobattoXW(x1, x2 float64, n int) (x, w []float64) {
+	if n < 2 {
+		chk.Panic("number of points for Gauss-Lobatto quadrature must be at least 2. n=%d is invalid\n", n)
+	}
+	x = make([]float64, n)
+	w = make([]float64, n)
+	EPS := MACHEPS // relative precision.
+	N := float64(n - 1)
+	var z1, z, xm, xl, p3, p2, p1 float64
+	m := (n + 1) / 2 // The points are symmetric in the interval, so we only have to find half of them.
+	xm = 0.5 * (x2 + x1)
+	xl = 0.5 * (x2 - x1)
+	for i := 0; i < m; i++ { // Loop over the desired points.
+		z = math.Cos(math.Pi * float64(i) / N) // Chebyshev-Gauss-Lobatto points are the initial guess
+		it, MAXIT := 0, 100
+		for it = 0; it < MAXIT; it++ {
+			p1 = 1.0
+			p2 = 0.0
+			for j := 0; j < n-1; j++ { // Loop up the recurrence relation to get P_{n-1} and P_{n-2}.
+				p3 = p2
+				p2 = p1
+				p1 = ((2.0*float64(j)+1.0)*z*p2 - float64(j)*p3) / (float64(j) + 1.0)
+			}
+			// p1 is now P_{n-1}(z) and p2 is P_{n-2}(z). Newton's method applied to (1-z²)⋅P'_{n-1}(z);
+			// note that z = ±1 are fixed points of this iteration.
+			z1 = z
+			z = z1 - (z1*p1-p2)/(float64(n)*p1)
+			if math.Abs(z-z1) < EPS {
+				break
+			}
+		}
+		if it == MAXIT {
+			chk.Panic("Newton's method did not converge after %d iterations", it)
+		}
+		x[i] = xm - xl*z // Scale the point to the desired interval, and put in its symmetric counterpart.
+		x[n-1-i] = xm + xl*z
+		w[i] = 2.0 * xl / (N * float64(n) * p1 * p1) // Compute the weight and its symmetric counterpart.
+		w[n-1-i] = w[i]
+	}
+	return
+}
+
+// GaussRadauXW computes positions (xi) and weights (wi) to perform Gauss-Radau-Legendre integrations.
+// One of the end points (x1 or x2) is included in the set of positions. The positions are sorted
+// in ascending order.
+//   Input:
+//     x1       -- lower limit of integration
+//     x2       -- upper limit of integration
+//     n        -- number of points for quadrature formula. n ≥ 1
+//     fixRight -- include x2 instead of x1 in the set of positions
+//   Note: the quadrature is exact for polynomials of degree up to 2n-2
+//   Reference:
+//   [1] Canuto C, Hussaini MY, Quarteroni A, Zang TA (2006) Spectral Methods: Fundamentals in
+//       Single Domains. Springer. 563p
+func GaussRadauXW(x1, x2 float64, n int, fixRight bool) (x, w []float64) {
+	if n < 1 {
+		chk.Panic("number of points for Gauss-Radau quadrature must be at least 1. n=%d is invalid\n", n)
+	}
+	x = make([]float64, n)
+	w = make([]float64, n)
+	EPS := MACHEPS // relative precision.
+	N := float64(n)
+	var z1, z, xm, xl, p3, p2, p1, p0, dp float64
+	xm = 0.5 * (x2 + x1)
+	xl = 0.5 * (x2 - x1)
+	z = -1.0                 // The first point is the fixed end point.
+	for i := 0; i < n; i++ { // Loop over the desired points.
+		if i > 0 {
+			z = -math.Cos(2.0 * math.Pi * float64(i) / (2.0*N - 1.0)) // Chebyshev-Gauss-Radau points are the initial guess
+			it, MAXIT := 0, 100
+			for it = 0; it < MAXIT; it++ {
+				p1 = 1.0
+				p2 = 0.0
+				p3 = 0.0
+				for j := 0; j < n; j++ { // Loop up the recurrence relation to get P_n, P_{n-1} and P_{n-2}.
+					p3 = p2
+					p2 = p1
+					p1 = ((2.0*float64(j)+1.0)*z*p2 - float64(j)*p3) / (float64(j) + 1.0)
+				}
+				// p1 is now P_n(z), p2 is P_{n-1}(z) and p3 is P_{n-2}(z). Newton's method applied to P_{n-1}(z) + P_n(z)
+				dp = (N*(z*p1-p2) + (N-1.0)*(z*p2-p3)) / (z*z - 1.0)
+				z1 = z
+				z = z1 - (p1+p2)/dp
+				if math.Abs(z-z1) < EPS {
+					break
+				}
+			}
+			if it == MAXIT {
+				chk.Panic("Newton's method did not converge after %d iterations", it)
+			}
+		}
+		p1 = 1.0
+		p0 = 0.0
+		for j := 0; j < n-1; j++ { // Loop up the recurrence relation to get P_{n-1}.
+			p3 = p0
+			p0 = p1
+			p1 = ((2.0*float64(j)+1.0)*z*p0 - float64(j)*p3) / (float64(j) + 1.0)
+		}
+		if fixRight { // Scale the point to the desired interval and compute the weight.
+			x[n-1-i] = xm - xl*z
+			w[n-1-i] = xl * (1.0 - z) / (N * N * p1 * p1)
+		} else {
+			x[i] = xm + xl*z
+			w[i] = xl * (1.0 - z) / (N * N * p1 * p1)
+		}
+	}
+	return
+}
+
+// GaussKronrodXW computes positions (xi) and weights to perform Gauss-Kronrod integrations. The
+// (2n+1)-point Kronrod rule contains the n positions of the Gauss-Legendre rule plus the n+1 roots
+// of the Stieltjes polynomial E_{n+1}. The positions are sorted in ascending order.
+//   Input:
+//     x1 -- lower limit of integration
+//     x2 -- upper limit of integration
+//     n  -- number of points of the underlying Gauss-Legendre rule. n ≥ 1
+//   Output:
+//     x  -- 2n+1 positions
+//     wk -- 2n+1 weights of the Kronrod rule
+//     wg -- 2n+1 weights of the Gauss rule; wg[i] = 0 if x[i] is not a Gauss-Legendre position
+//   Note: the Kronrod rule is exact for polynomials of degree up to 3n+1 (n even) or 3n+2 (n odd)
+//         and |I_kronrod - I_gauss| can be used as an error estimate
+//   Reference:
+//   [1] Monegato G (1978) Some remarks on the construction of extended Gaussian quadrature rules.
+//       Mathematics of Computation, 32(141):247-252
+func GaussKronrodXW(x1, x2 float64, n int) (x, wk, wg []float64) {
+	if n < 1 {
+		chk.Panic("number of points for Gauss-Kronrod quadrature must be at least 1. n=%d is invalid\n", n)
+	}
+
+	// Gauss-Legendre rule and Legendre polynomials P_0 ... P_{n+1} at auxiliary points
+	xg, wgs := GaussLegendreXW(-1, 1, n)
+	xa, wa := GaussLegendreXW(-1, 1, 2*n+2) // exact for ∫ P_n⋅P_j⋅P_k with j,k ≤ n+1
+	Pa := make([][]float64, len(xa))
+	for m, z := range xa {
+		Pa[m] = legendreValues(nil, z, n+1)
+	}
+
+	// Stieltjes polynomial: E_{n+1} = P_{n+1} + Σ a_j P_j with j = n-1, n-3, ... which satisfies
+	// ∫ P_n⋅E_{n+1}⋅P_k dx = 0 for k = 1, 3, ... ≤ n; the other coefficients vanish by symmetry
+	var jj, kk []int
+	for j := n - 1; j >= 0; j -= 2 {
+		jj = append(jj, j)
+	}
+	for k := 1; k <= n; k += 2 {
+		kk = append(kk, k)
+	}
+	nc := len(jj)
+	A := make([][]float64, nc)
+	b := make([]float64, nc)
+	for r, k := range kk {
+		A[r] = make([]float64, nc)
+		for m := range xa {
+			c := wa[m] * Pa[m][n] * Pa[m][k]
+			for q, j := range jj {
+				A[r][q] += c * Pa[m][j]
+			}
+			b[r] -= c * Pa[m][n+1]
+		}
+	}
+	a := gaussElimination(A, b)
+	P := make([]float64, n+2)
+	stieltjes := func(z float64) (res float64) {
+		legendreValues(P, z, n+1)
+		res = P[n+1]
+		for q, j := range jj {
+			res += a[q] * P[j]
+		}
+		return
+	}
+
+	// roots of E_{n+1} interlace with the Gauss-Legendre positions
+	z := make([]float64, 2*n+1)
+	isGauss := make([]bool, 2*n+1)
+	for i := 0; i <= n; i++ {
+		lo, hi := -1.0, 1.0
+		if i > 0 {
+			lo = xg[i-1]
+		}
+		if i < n {
+			hi = xg[i]
+		}
+		flo := stieltjes(lo)
+		for {
+			mid := (lo + hi) / 2.0
+			if mid <= lo || mid >= hi {
+				break
+			}
+			fmid := stieltjes(mid)
+			if fmid == 0 {
+				lo, hi = mid, mid
+				break
+			}
+			if (fmid > 0) == (flo > 0) {
+				lo, flo = mid, fmid
+			} else {
+				hi = mid
+			}
+		}
+		z[2*i] = (lo + hi) / 2.0
+		if i < n {
+			z[2*i+1] = xg[i]
+			isGauss[2*i+1] = true
+		}
+	}
+
+	// Kronrod weights: exactness for P_0 ... P_{2n}
+	np := 2*n + 1
+	V := make([][]float64, np)
+	rhs := make([]float64, np)
+	rhs[0] = 2.0
+	for k := 0; k < np; k++ {
+		V[k] = make([]float64, np)
+	}
+	Pv := make([]float64, np)
+	for i := 0; i < np; i++ {
+		legendreValues(Pv, z[i], np-1)
+		for k := 0; k < np; k++ {
+			V[k][i] = Pv[k]
+		}
+	}
+	wkr := gaussElimination(V, rhs)
+
+	// results
+	xm := 0.5 * (x2 + x1)
+	xl := 0.5 * (x2 - x1)
+	x = make([]float64, np)
+	wk = make([]float64, np)
+	wg = make([]float64, np)
+	for i := 0; i < np; i++ {
+		x[i] = xm + xl*z[i]
+		wk[i] = xl * wkr[i]
+		if isGauss[i] {
+			wg[i] = xl * wgs[i/2]
+		}
+	}
+	return
+}
+
+// legendreValues computes the Legendre polynomials P_0(z) ... P_n(z)
+//   P -- output [n+1]; may be nil
+func legendreValues(P []float64, z float64, n int) []float64 {
+	if P == nil {
+		P = make([]float64, n+1)
+	}
+	P[0] = 1.0
+	if n > 0 {
+		P[1] = z
+	}
+	for j := 1; j < n; j++ {
+		P[j+1] = ((2.0*float64(j)+1.0)*z*P[j] - float64(j)*P[j-1]) / (float64(j) + 1.0)
+	}
+	return P
+}
+
+// gaussElimination solves the small dense system A⋅x = b by Gaussian elimination with partial
+// pivoting. A and b are modified
+func gaussElimination(A [][]float64, b []float64) (x []float64) {
+	n := len(b)
+	for c := 0; c < n; c++ {
+		p := c
+		for r := c + 1; r < n; r++ {
+			if math.Abs(A[r][c]) > math.Abs(A[p][c]) {
+				p = r
+			}
+		}
+		if A[p][c] == 0 {
+			chk.Panic("matrix is singular\n")
+		}
+		A[c], A[p] = A[p], A[c]
+		b[c], b[p] = b[p], b[c]
+		for r := c + 1; r < n; r++ {
+			f := A[r][c] / A[c][c]
+			for k := c; k < n; k++ {
+				A[r][k] -= f * A[c][k]
+			}
+			b[r] -= f * b[c]
+		}
+	}
+	x = make([]float64, n)
+	for r := n - 1; r >= 0; r-- {
+		sum := b[r]
+		for k := r + 1; k < n; k++ {
+			sum -= A[r][k] * x[k]
+		}
+		x[r] = sum / A[r][r]
+	}
+	return
+}
+
 // GaussJacobiXW computes positions (xi) and weights (wi) to perform Gauss-Jacobi integrations.
 // The largest abscissa is returned in x[0], the smallest in x[n-1].
 // The interval of integration is x ϵ [-1, 1]
@@ -175,3 +494,147 @@ func GaussJacobiXW(alf, bet float64, n int) (x, w []float64) {
 	utl.Qsort2(x, w)
 	return
 }
+
+// GaussLaguerreXW computes positions (xi) and weights (wi) to perform Gauss-Laguerre integrations.
+// The positions are sorted in ascending order.
+//
+//   Computes:
+//
+//      ∞
+//     ⌠  α  -x             n-1
+//     │ x ⋅e  ⋅f(x) dx ≈    Σ  f(xi)⋅wi
+//     ⌡                    i=0
+//      0
+//
+//   Input:
+//     alf -- coefficient α of the generalised Laguerre polynomial. alf > -1
+//     n   -- number of points for quadrature formula
+//   Note: the weight function x^α⋅exp(-x) is absorbed in the weights; i.e. only f(x) must be evaluated.
+//         The quadrature is exact for polynomials f(x) of degree up to 2n-1
+//   Reference:
+//   [1] Press WH, Teukolsky SA, Vetterling WT, Fnannery BP (2007) Numerical Recipes: The Art of
+//       Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+func GaussLaguerreXW(alf float64, n int) (x, w []float64) {
+	if n < 1 || alf <= -1 {
+		chk.Panic("Gauss-Laguerre quadrature requires n ≥ 1 and alf > -1. n=%d and alf=%g are invalid\n", n, alf)
+	}
+	x = make([]float64, n)
+	w = make([]float64, n)
+	EPS := 1e-14      // relative precision
+	ROUNDOFF := 1e-10 // relative size of steps considered to be dominated by round-off errors
+	N := float64(n)
+	var ai, p1, p2, p3, pp, z, z1 float64
+	for i := 0; i < n; i++ { // Loop over the desired roots.
+		if i == 0 { // Initial guess for the smallest root.
+			z = (1.0 + alf) * (3.0 + 0.92*alf) / (1.0 + 2.4*N + 1.8*alf)
+		} else if i == 1 { // Initial guess for the second root.
+			z += (15.0 + 6.25*alf) / (1.0 + 0.9*alf + 2.5*N)
+		} else { // Initial guess for the other roots.
+			ai = float64(i - 1)
+			z += ((1.0+2.55*ai)/(1.9*ai) + 1.26*ai*alf/(1.0+3.5*ai)) * (z - x[i-2]) / (1.0 + 0.3*alf)
+		}
+		it, MAXIT := 0, 100
+		dzOld := math.Inf(1)
+		for it = 0; it < MAXIT; it++ { // Refinement by Newton's method.
+			p1 = 1.0
+			p2 = 0.0
+			for j := 0; j < n; j++ { // Loop up the recurrence relation to get the Laguerre polynomial evaluated at z.
+				p3 = p2
+				p2 = p1
+				p1 = ((2.0*float64(j)+1.0+alf-z)*p2 - (float64(j)+alf)*p3) / (float64(j) + 1.0)
+			}
+			// p1 is now the desired Laguerre polynomial. We next compute pp, its derivative, by a
+			// standard relation involving also p2, the polynomial of one lower order.
+			pp = (N*p1 - (N+alf)*p2) / z
+			z1 = z
+			z = z1 - p1/pp // Newton's formula.
+			dz := math.Abs(z - z1)
+			if dz <= EPS*math.Abs(z) {
+				break
+			}
+			// the step does not shrink anymore because of round-off errors in the recurrence
+			if dz <= ROUNDOFF*math.Abs(z) && dz >= dzOld {
+				break
+			}
+			dzOld = dz
+		}
+		if it == MAXIT {
+			chk.Panic("Newton's method did not converge after %d iterations", it)
+		}
+		x[i] = z // Store the root and the weight.
+		l1, _ := math.Lgamma(alf + N)
+		l2, _ := math.Lgamma(N)
+		w[i] = -math.Exp(l1-l2) / (pp * N * p2)
+	}
+	return
+}
+
+// GaussHermiteXW computes positions (xi) and weights (wi) to perform Gauss-Hermite integrations.
+// The largest abscissa is returned in x[0], the most negative in x[n-1].
+//
+//   Computes:
+//
+//     +∞
+//     ⌠   -x²             n-1
+//     │  e   ⋅f(x) dx ≈    Σ  f(xi)⋅wi
+//     ⌡                   i=0
+//     -∞
+//
+//   Input:
+//     n -- number of points for quadrature formula
+//   Note: the weight function exp(-x²) is absorbed in the weights; i.e. only f(x) must be evaluated.
+//         The quadrature is exact for polynomials f(x) of degree up to 2n-1
+//   Reference:
+//   [1] Press WH, Teukolsky SA, Vetterling WT, Fnannery BP (2007) Numerical Recipes: The Art of
+//       Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+func GaussHermiteXW(n int) (x, w []float64) {
+	if n < 1 {
+		chk.Panic("number of points for Gauss-Hermite quadrature must be at least 1. n=%d is invalid\n", n)
+	}
+	x = make([]float64, n)
+	w = make([]float64, n)
+	EPS := 1e-14               // relative precision
+	PIM4 := 0.7511255444649425 // 1/π^(1/4)
+	N := float64(n)
+	var p1, p2, p3, pp, z, z1 float64
+	m := (n + 1) / 2         // The roots are symmetric about the origin, so we only have to find half of them.
+	for i := 0; i < m; i++ { // Loop over the desired roots.
+		if i == 0 { // Initial guess for the largest root.
+			z = math.Sqrt(2.0*N+1.0) - 1.85575*math.Pow(2.0*N+1.0, -0.16667)
+		} else if i == 1 { // Initial guess for the second largest root.
+			z -= 1.14 * math.Pow(N, 0.426) / z
+		} else if i == 2 { // Initial guess for the third largest root.
+			z = 1.86*z - 0.86*x[0]
+		} else if i == 3 { // Initial guess for the fourth largest root.
+			z = 1.91*z - 0.91*x[1]
+		} else { // Initial guess for the other roots.
+			z = 2.0*z - x[i-2]
+		}
+		it, MAXIT := 0, 100
+		for it = 0; it < MAXIT; it++ { // Refinement by Newton's method.
+			p1 = PIM4
+			p2 = 0.0
+			for j := 0; j < n; j++ { // Loop up the recurrence relation to get the Hermite polynomial evaluated at z.
+				p3 = p2
+				p2 = p1
+				p1 = z*math.Sqrt(2.0/(float64(j)+1.0))*p2 - math.Sqrt(float64(j)/(float64(j)+1.0))*p3
+			}
+			// p1 is now the desired (normalised) Hermite polynomial. We next compute pp, its
+			// derivative, by the relation (4.6.21) of [1] using p2, the polynomial of one lower order.
+			pp = math.Sqrt(2.0*N) * p2
+			z1 = z
+			z = z1 - p1/pp // Newton's formula.
+			if math.Abs(z-z1) <= EPS*math.Max(1.0, math.Abs(z)) {
+				break
+			}
+		}
+		if it == MAXIT {
+			chk.Panic("Newton's method did not converge after %d iterations", it)
+		}
+		x[i] = z // Store the root and its symmetric counterpart.
+		x[n-1-i] = -z
+		w[i] = 2.0 / (pp * pp) // Compute the weight and its symmetric counterpart.
+		w[n-1-i] = w[i]
+	}
+	return
+}
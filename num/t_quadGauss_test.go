@@ -6,6 +6,7 @@ package num
 
 import (
 	"math"
+	"math/big"
 	"testing"
 
 	"github.com/cpmech/gosl/chk"
@@ -45,3 +46,270 @@ func Test_gaussLegXW01(tst *testing.T) {
 	chk.Array(tst, "xJ", 1e-15, xJ, xRef)
 	chk.Array(tst, "wJ", 1e-14, wJ, wRef)
 }
+
+func Test_gaussLobXW01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("gaussLobXW01. Gauss-Lobatto x-w data.")
+
+	// constants
+	a := math.Sqrt(3.0 / 7.0)
+	xRef := []float64{-1, -a, 0, a, 1}
+	wRef := []float64{1.0 / 10.0, 49.0 / 90.0, 32.0 / 45.0, 49.0 / 90.0, 1.0 / 10.0}
+
+	x, w := GaussLobattoXW(-1, 1, 5)
+	io.Pforan("x = %v\n", x)
+	io.Pforan("w = %v\n", w)
+	chk.Array(tst, "x", 1e-15, x, xRef)
+	chk.Array(tst, "w", 1e-15, w, wRef)
+
+	// end points and exactness up to degree 2n-3
+	for n := 2; n <= 12; n++ {
+		x, w = GaussLobattoXW(2, 5, n)
+		chk.Float64(tst, io.Sf("n=%2d: x[0]", n), 1e-15, x[0], 2)
+		chk.Float64(tst, io.Sf("n=%2d: x[n-1]", n), 1e-15, x[n-1], 5)
+		for k := 0; k <= 2*n-3; k++ {
+			res := 0.0
+			for i := 0; i < n; i++ {
+				res += w[i] * math.Pow(x[i], float64(k))
+			}
+			ana := (math.Pow(5, float64(k+1)) - math.Pow(2, float64(k+1))) / float64(k+1)
+			chk.Float64(tst, io.Sf("n=%2d: ∫x^%d / ana", n, k), 1e-14, res/ana, 1)
+		}
+	}
+
+	// check panic
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		GaussLobattoXW(-1, 1, 1)
+	}()
+}
+
+func Test_gaussRadXW01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("gaussRadXW01. Gauss-Radau x-w data.")
+
+	// constants
+	xRef := []float64{-1, (1 - math.Sqrt(6)) / 5, (1 + math.Sqrt(6)) / 5}
+	wRef := []float64{2.0 / 9.0, (16 + math.Sqrt(6)) / 18, (16 - math.Sqrt(6)) / 18}
+
+	x, w := GaussRadauXW(-1, 1, 3, false)
+	io.Pforan("x = %v\n", x)
+	io.Pforan("w = %v\n", w)
+	chk.Array(tst, "x", 1e-15, x, xRef)
+	chk.Array(tst, "w", 1e-15, w, wRef)
+
+	x, w = GaussRadauXW(-1, 1, 3, true)
+	chk.Array(tst, "x (right)", 1e-15, x, []float64{-xRef[2], -xRef[1], -xRef[0]})
+	chk.Array(tst, "w (right)", 1e-15, w, []float64{wRef[2], wRef[1], wRef[0]})
+
+	// end points and exactness up to degree 2n-2
+	for _, fixRight := range []bool{false, true} {
+		for n := 1; n <= 12; n++ {
+			x, w = GaussRadauXW(2, 5, n, fixRight)
+			if fixRight {
+				chk.Float64(tst, io.Sf("n=%2d: x[n-1]", n), 1e-15, x[n-1], 5)
+			} else {
+				chk.Float64(tst, io.Sf("n=%2d: x[0]", n), 1e-15, x[0], 2)
+			}
+			for k := 0; k <= 2*n-2; k++ {
+				res := 0.0
+				for i := 0; i < n; i++ {
+					res += w[i] * math.Pow(x[i], float64(k))
+				}
+				ana := (math.Pow(5, float64(k+1)) - math.Pow(2, float64(k+1))) / float64(k+1)
+				chk.Float64(tst, io.Sf("n=%2d: ∫x^%d / ana", n, k), 1e-14, res/ana, 1)
+			}
+		}
+	}
+}
+
+func TestGaussKronrod01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("GaussKronrod01. Gauss-Kronrod points and weights")
+
+	// G7-K15 from QUADPACK
+	x, wk, wg := GaussKronrodXW(-1, 1, 7)
+	chk.Float64(tst, "x[14]", 1e-15, x[14], 0.991455371120812639206854697526329)
+	chk.Float64(tst, "x[13]", 1e-15, x[13], 0.949107912342758524526189684047851)
+	chk.Float64(tst, "x[7]", 1e-15, x[7], 0)
+	chk.Float64(tst, "wk[7]", 1e-15, wk[7], 0.209482141084727828012999174891714)
+	chk.Float64(tst, "wk[14]", 1e-15, wk[14], 0.022935322010529224963732008058970)
+	chk.Float64(tst, "wg[7]", 1e-15, wg[7], 0.417959183673469387755102040816327)
+	chk.Float64(tst, "wg[14]", 1e-15, wg[14], 0)
+
+	// Gauss positions and exactness
+	for n := 1; n <= 12; n++ {
+		x, wk, wg = GaussKronrodXW(2, 5, n)
+		xg, wgRef := GaussLegendreXW(2, 5, n)
+		for i := 0; i < n; i++ {
+			chk.Float64(tst, io.Sf("n=%2d: xg[%d]", n, i), 1e-14, x[2*i+1], xg[i])
+			chk.Float64(tst, io.Sf("n=%2d: wg[%d]", n, i), 1e-14, wg[2*i+1], wgRef[i])
+		}
+		degree := 3*n + 1
+		if n%2 == 1 {
+			degree++
+		}
+		for k := 0; k <= degree; k++ {
+			resK, resG := 0.0, 0.0
+			for i := 0; i < len(x); i++ {
+				resK += wk[i] * math.Pow(x[i], float64(k))
+				resG += wg[i] * math.Pow(x[i], float64(k))
+			}
+			ana := (math.Pow(5, float64(k+1)) - math.Pow(2, float64(k+1))) / float64(k+1)
+			chk.Float64(tst, io.Sf("n=%2d: ∫x^%d / ana", n, k), 1e-13, resK/ana, 1)
+			if k <= 2*n-1 {
+				chk.Float64(tst, io.Sf("n=%2d: ∫x^%d / ana (Gauss)", n, k), 1e-13, resG/ana, 1)
+			}
+		}
+	}
+}
+
+func TestGaussLaguerreHermite01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("GaussLaguerreHermite01. Gauss-Laguerre and Gauss-Hermite points and weights")
+
+	// Gauss-Laguerre: ∫ x^k⋅x^α⋅exp(-x) dx over [0,∞) = Γ(k+α+1)
+	for _, alf := range []float64{-0.5, 0, 0.5, 2} {
+		for n := 1; n <= 12; n++ {
+			x, w := GaussLaguerreXW(alf, n)
+			for i := 1; i < n; i++ {
+				if x[i] <= x[i-1] {
+					tst.Errorf("positions must be sorted in ascending order\n")
+					return
+				}
+			}
+			for k := 0; k <= 2*n-1; k++ {
+				res := 0.0
+				for i := 0; i < n; i++ {
+					res += w[i] * math.Pow(x[i], float64(k))
+				}
+				ana := math.Gamma(float64(k) + alf + 1.0)
+				chk.Float64(tst, io.Sf("α=%g n=%2d: ∫x^%d⋅w(x) / ana", alf, n, k), 1e-11, res/ana, 1)
+			}
+		}
+	}
+
+	// Gauss-Laguerre with many points: Newton's method must converge despite round-off errors
+	for _, alf := range []float64{-0.5, 0, 0.5, 2} {
+		for n := 13; n <= 100; n++ {
+			x, w := GaussLaguerreXW(alf, n)
+			for i := 1; i < n; i++ {
+				if x[i] <= x[i-1] || w[i] < 0 {
+					tst.Errorf("α=%g n=%d: positions must be sorted in ascending order and weights must be non-negative\n", alf, n)
+					return
+				}
+			}
+			for k := 0; k <= 2; k++ {
+				res := 0.0
+				for i := 0; i < n; i++ {
+					res += w[i] * math.Pow(x[i], float64(k))
+				}
+				ana := math.Gamma(float64(k) + alf + 1.0)
+				chk.Float64(tst, io.Sf("α=%g n=%3d: ∫x^%d⋅w(x) / ana", alf, n, k), 1e-11, res/ana, 1)
+			}
+		}
+	}
+
+	// Gauss-Hermite: ∫ x^k⋅exp(-x²) dx over (-∞,∞) = Γ((k+1)/2) if k is even or 0 if k is odd
+	x, w := GaussHermiteXW(2)
+	chk.Array(tst, "x", 1e-15, x, []float64{1 / math.Sqrt2, -1 / math.Sqrt2})
+	chk.Array(tst, "w", 1e-15, w, []float64{math.Sqrt(math.Pi) / 2, math.Sqrt(math.Pi) / 2})
+	for n := 1; n <= 20; n++ {
+		x, w = GaussHermiteXW(n)
+		for k := 0; k <= 2*n-1; k++ {
+			res, scale := 0.0, 0.0
+			for i := 0; i < n; i++ {
+				res += w[i] * math.Pow(x[i], float64(k))
+				scale += math.Abs(w[i] * math.Pow(x[i], float64(k)))
+			}
+			ana := 0.0
+			if k%2 == 0 {
+				ana = math.Gamma((float64(k) + 1.0) / 2.0)
+			}
+			chk.Float64(tst, io.Sf("n=%2d: ∫x^%d⋅w(x)", n, k), 1e-13*scale, res, ana)
+		}
+	}
+}
+
+func Test_gaussLegXW02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("gaussLegXW02. high order Gauss-Legendre points")
+
+	// the residual |P_n(x_i)| of the exact root rounded to float64 is up to |P'_n(x_i)|⋅ulp(x_i)/2;
+	// e.g. about 5e-14 for n=64. Thus, the residual is compared with 1e-14 plus this unavoidable part.
+	// The residual is computed with extended precision because the recurrence in float64 has
+	// round-off errors of about 1e-14 for n=64
+	for n := 1; n <= 64; n++ {
+		x, w, err := GaussLegendreXWerr(-1, 1, n)
+		if err != nil {
+			tst.Errorf("n=%d: %v\n", n, err)
+			return
+		}
+		N := float64(n)
+		errMax, resMax := 0.0, 0.0
+		for i, z := range x {
+			p1, p2 := 1.0, 0.0
+			for j := 0; j < n; j++ {
+				p1, p2 = ((2.0*float64(j)+1.0)*z*p1-float64(j)*p2)/(float64(j)+1.0), p1
+			}
+			pp := N * (z*p1 - p2) / (z*z - 1.0)
+			ulp := math.Nextafter(math.Abs(z), 2) - math.Abs(z)
+			resMax = math.Max(resMax, math.Abs(legendreBig(n, z))-math.Abs(pp)*ulp/2)
+			errMax = math.Max(errMax, math.Abs(p1/pp)) // Newton correction ≈ error of position
+			if i > 0 && z <= x[i-1] {
+				tst.Errorf("n=%d: positions must be sorted in ascending order\n", n)
+				return
+			}
+		}
+		io.Pforan("n = %2d: max |P_n(x_i)| - |P'_n(x_i)|⋅ulp(x_i)/2 = %.3e, max |P_n(x_i)/P'_n(x_i)| = %.3e\n", n, resMax, errMax)
+		if errMax > 1e-15 {
+			tst.Errorf("n=%d: positions are not accurate enough: error = %g\n", n, errMax)
+		}
+		if resMax > 1e-14 {
+			tst.Errorf("n=%d: residuals are too large: |P_n(x_i)| - |P'_n(x_i)|⋅ulp(x_i)/2 = %g\n", n, resMax)
+		}
+
+		// ∫ P_{n-1}² dx = 2 / (2n-1)
+		res := 0.0
+		for i, z := range x {
+			p1, p2 := 1.0, 0.0
+			for j := 0; j < n-1; j++ {
+				p1, p2 = ((2.0*float64(j)+1.0)*z*p1-float64(j)*p2)/(float64(j)+1.0), p1
+			}
+			res += w[i] * p1 * p1
+		}
+		chk.Float64(tst, io.Sf("n=%2d: ∫P²", n), 1e-14, res, 2.0/(2.0*N-1.0))
+	}
+
+	// errors
+	if _, _, err := GaussLegendreXWerr(-1, 1, 0); err == nil {
+		tst.Errorf("n=0 should have been rejected\n")
+	}
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		GaussLegendreXW(-1, 1, 0)
+	}()
+}
+
+// legendreBig computes the Legendre polynomial P_n(z) with extended precision
+func legendreBig(n int, z float64) float64 {
+	prec := uint(256)
+	bz := new(big.Float).SetPrec(prec).SetFloat64(z)
+	p1 := new(big.Float).SetPrec(prec).SetInt64(1)
+	p2 := new(big.Float).SetPrec(prec)
+	for j := 0; j < n; j++ {
+		a := new(big.Float).SetPrec(prec).Mul(bz, p1)
+		a.Mul(a, new(big.Float).SetInt64(int64(2*j+1)))
+		b := new(big.Float).SetPrec(prec).Mul(p2, new(big.Float).SetInt64(int64(j)))
+		a.Sub(a, b)
+		a.Quo(a, new(big.Float).SetInt64(int64(j+1)))
+		p1, p2 = a, p1
+	}
+	res, _ := p1.Float64()
+	return res
+}